@@ -0,0 +1,243 @@
+// Command jsonschema2go generates Go code from JSON schemas and provides related schema tooling.
+//
+// Usage:
+//
+//	jsonschema2go [generate] [flags] SCHEMA...
+//	jsonschema2go bundle [flags] SCHEMA
+//	jsonschema2go explain [flags] SCHEMA[#POINTER]
+//	jsonschema2go graph [flags] SCHEMA...
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ns1/jsonschema2go"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+func main() {
+	log.SetFlags(0)
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	cmd := "generate"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		switch args[0] {
+		case "generate", "bundle", "explain", "graph":
+			cmd, args = args[0], args[1:]
+		}
+	}
+
+	switch cmd {
+	case "bundle":
+		return bundle(ctx, args, stdout)
+	case "explain":
+		return explain(ctx, args, stdout)
+	case "graph":
+		return graph(ctx, args, stdout)
+	default:
+		return generate(ctx, args)
+	}
+}
+
+func generate(ctx context.Context, args []string) error {
+	var (
+		flags         = flag.NewFlagSet("generate", flag.ContinueOnError)
+		planning      = planningFlags(flags)
+		fileName      = flags.String("file-name", "", "pattern used to name generated files; * is replaced with the file's base name")
+		split         = flags.Bool("split", false, "generate one file per top level schema")
+		selfContained = flags.Bool("self-contained", false, "render validation error types into each generated package")
+		clean         = flags.Bool("clean", false, "remove stale generated files")
+		mergeCycles   = flags.Bool("merge-cycles", false, "generate packages which would import each other as one package")
+		disambiguate  = flags.Bool("disambiguate", false, "number types whose names collide, e.g. Bar2, rather than failing")
+		debug         = flags.Bool("debug", false, "enable debug logging")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: jsonschema2go [generate] [flags] SCHEMA...")
+	}
+
+	opts, err := planning()
+	if err != nil {
+		return err
+	}
+	opts = append(opts,
+		jsonschema2go.SplitFiles(*split),
+		jsonschema2go.SelfContained(*selfContained),
+		jsonschema2go.CleanStale(*clean),
+		jsonschema2go.MergeImportCycles(*mergeCycles),
+		jsonschema2go.Debug(*debug),
+	)
+	if *disambiguate {
+		opts = append(opts, jsonschema2go.DisambiguateTypes(jsonschema2go.NumberSuffix))
+	}
+	if *fileName != "" {
+		opts = append(opts, jsonschema2go.FileName(*fileName))
+	}
+	return jsonschema2go.Generate(ctx, flags.Args(), opts...)
+}
+
+// planningFlags registers the flags which affect how schemas are planned, so that every subcommand which plans
+// schemas does so as generate would. The returned function returns the options they select once parsed.
+func planningFlags(flags *flag.FlagSet) func() ([]jsonschema2go.Option, error) {
+	var (
+		prefixes    pairs
+		titles      = flags.Bool("titles", false, "name types after the titles of their schemas")
+		pointers    = flags.String("pointers", "", "type primitive fields as pointers: always, optional, generic or nullable")
+		goVersion   = flags.String("go", "", "the Go version targeted by generated code, e.g. 1.18 for generics")
+		initialisms = flags.String("initialisms", "", "comma separated initialisms to upper case in names, e.g. ARN,SKU")
+	)
+	flags.Var(&prefixes, "prefix", "map a Go path prefix to a directory, as PREFIX=DIR; may be repeated")
+
+	return func() ([]jsonschema2go.Option, error) {
+		opts := []jsonschema2go.Option{jsonschema2go.TitleNames(*titles)}
+		if *pointers != "" {
+			policy := gen.PointerPolicy(*pointers)
+			if !policy.Valid() {
+				return nil, fmt.Errorf("unknown pointer policy %q", *pointers)
+			}
+			opts = append(opts, jsonschema2go.Pointers(policy))
+		}
+		if *goVersion != "" {
+			opts = append(opts, jsonschema2go.GoVersion(*goVersion))
+		}
+		if *initialisms != "" {
+			opts = append(opts, jsonschema2go.AdditionalInitialisms(strings.Split(*initialisms, ",")...))
+		}
+		if len(prefixes) > 0 {
+			opts = append(opts, jsonschema2go.PrefixMap(prefixes...))
+		}
+		return opts, nil
+	}
+}
+
+func bundle(ctx context.Context, args []string, stdout io.Writer) error {
+	var (
+		flags  = flag.NewFlagSet("bundle", flag.ContinueOnError)
+		output = flags.String("o", "", "file to write the bundled schema to; defaults to stdout")
+		debug  = flags.Bool("debug", false, "enable debug logging")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: jsonschema2go bundle [flags] SCHEMA")
+	}
+
+	return writeOutput(*output, stdout, func(w io.Writer) error {
+		return jsonschema2go.Bundle(ctx, flags.Arg(0), w, jsonschema2go.Debug(*debug))
+	})
+}
+
+// writeOutput calls write with the named file, or with stdout if no file is named. The output is written to a
+// temporary file beside the named one which replaces it only once it's complete, so that a failure leaves any
+// existing file untouched.
+func writeOutput(name string, stdout io.Writer, write func(w io.Writer) error) (err error) {
+	if name == "" {
+		return write(stdout)
+	}
+	f, err := ioutil.TempFile(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if err := write(f); err != nil {
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to write %q: %w", name, err)
+	}
+	return os.Rename(f.Name(), name)
+}
+
+func explain(ctx context.Context, args []string, stdout io.Writer) error {
+	var (
+		flags    = flag.NewFlagSet("explain", flag.ContinueOnError)
+		planning = planningFlags(flags)
+		format   = flags.String("format", "text", "output format, text or json")
+		debug    = flags.Bool("debug", false, "enable debug logging")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: jsonschema2go explain [flags] SCHEMA[#POINTER]")
+	}
+	opts, err := planning()
+	if err != nil {
+		return err
+	}
+	return jsonschema2go.Explain(
+		ctx,
+		flags.Arg(0),
+		stdout,
+		append(opts, jsonschema2go.OutputFormat(*format), jsonschema2go.Debug(*debug))...,
+	)
+}
+
+func graph(ctx context.Context, args []string, stdout io.Writer) error {
+	var (
+		flags    = flag.NewFlagSet("graph", flag.ContinueOnError)
+		planning = planningFlags(flags)
+		output   = flags.String("o", "", "file to write the graph to; defaults to stdout")
+		format   = flags.String("format", "dot", "output format, dot or json")
+		debug    = flags.Bool("debug", false, "enable debug logging")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: jsonschema2go graph [flags] SCHEMA...")
+	}
+	opts, err := planning()
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, stdout, func(w io.Writer) error {
+		return jsonschema2go.Graph(
+			ctx,
+			flags.Args(),
+			w,
+			append(opts, jsonschema2go.OutputFormat(*format), jsonschema2go.Debug(*debug))...,
+		)
+	})
+}
+
+// pairs is a repeatable flag of KEY=VALUE pairs, flattened into a list
+type pairs []string
+
+func (p *pairs) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *pairs) Set(v string) error {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("expected KEY=VALUE but got %q", v)
+	}
+	*p = append(*p, parts[0], parts[1])
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain runs the command itself rather than the tests when asked to by a test checking its exit code
+func TestMain(m *testing.M) {
+	if os.Getenv("JSONSCHEMA2GO_TEST_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// schemas writes a schema referring to another into a temporary directory, returning the directory and the path of
+// the referring schema
+func schemas(t *testing.T) (string, string) {
+	dir, err := ioutil.TempDir("", "jsonschema2go")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	for name, schema := range map[string]string{
+		"bar.json": `{
+  "id": "https://example.com/foo/bar.json",
+  "type": "object",
+  "properties": {
+    "name": {"type": "string"},
+    "baz": {"$ref": "baz.json"}
+  }
+}`,
+		"baz.json": `{
+  "id": "https://example.com/foo/baz.json",
+  "type": "object",
+  "properties": {"count": {"type": "integer"}}
+}`,
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(schema), 0644))
+	}
+	return dir, filepath.Join(dir, "bar.json")
+}
+
+func TestRun_generate(t *testing.T) {
+	for name, args := range map[string][]string{"default": nil, "generate": {"generate"}} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+			dir, schema := schemas(t)
+			out := filepath.Join(dir, "out")
+
+			var stdout bytes.Buffer
+			r.NoError(run(context.Background(), append(args, "-prefix", "example.com/foo="+out, schema), &stdout))
+			r.Empty(stdout.String())
+
+			b, err := ioutil.ReadFile(filepath.Join(out, "values.gen.go"))
+			r.NoError(err)
+			r.Contains(string(b), "type Bar struct")
+			r.Contains(string(b), "type Baz struct")
+		})
+	}
+}
+
+func TestRun_bundle(t *testing.T) {
+	r := require.New(t)
+	dir, schema := schemas(t)
+
+	var stdout bytes.Buffer
+	r.NoError(run(context.Background(), []string{"bundle", schema}, &stdout))
+
+	var bundled struct {
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	r.NoError(json.Unmarshal(stdout.Bytes(), &bundled))
+	r.Contains(bundled.Defs, "https://example.com/foo/baz.json")
+
+	// written to the named file instead
+	stdout.Reset()
+	name := filepath.Join(dir, "bundled.json")
+	r.NoError(run(context.Background(), []string{"bundle", "-o", name, schema}, &stdout))
+	r.Empty(stdout.String())
+	b, err := ioutil.ReadFile(name)
+	r.NoError(err)
+	r.Contains(string(b), `"https://example.com/foo/baz.json"`)
+
+	// a failure leaves the named file untouched
+	r.Error(run(context.Background(), []string{"bundle", "-o", name, filepath.Join(dir, "missing.json")}, &stdout))
+	after, err := ioutil.ReadFile(name)
+	r.NoError(err)
+	r.Equal(b, after)
+}
+
+func TestRun_graph(t *testing.T) {
+	r := require.New(t)
+	dir, schema := schemas(t)
+
+	var stdout bytes.Buffer
+	r.NoError(run(context.Background(), []string{"graph", "-format", "json", schema}, &stdout))
+	r.JSONEq(`{
+		"nodes": [
+			{"id": "https://example.com/foo/bar.json", "goPath": "example.com/foo", "name": "Bar"},
+			{"id": "https://example.com/foo/baz.json", "goPath": "example.com/foo", "name": "Baz"}
+		],
+		"edges": [{"from": "https://example.com/foo/bar.json", "to": "https://example.com/foo/baz.json"}]
+	}`, stdout.String())
+
+	// written to the named file instead
+	stdout.Reset()
+	name := filepath.Join(dir, "graph.dot")
+	r.NoError(run(context.Background(), []string{"graph", "-o", name, schema}, &stdout))
+	r.Empty(stdout.String())
+	b, err := ioutil.ReadFile(name)
+	r.NoError(err)
+	r.Contains(string(b), `"https://example.com/foo/bar.json" -> "https://example.com/foo/baz.json";`)
+}
+
+func TestRun_explain(t *testing.T) {
+	r := require.New(t)
+	_, schema := schemas(t)
+
+	var stdout bytes.Buffer
+	r.NoError(run(context.Background(), []string{"explain", "-pointers", "optional", schema}, &stdout))
+	r.Contains(stdout.String(), "planner:   object")
+	r.Contains(stdout.String(), "type:      example.com/foo.Bar")
+}
+
+func TestRun_errors(t *testing.T) {
+	_, schema := schemas(t)
+
+	for _, tt := range []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "no schemas", wantErr: "usage: jsonschema2go [generate] [flags] SCHEMA..."},
+		{
+			name:    "malformed prefix",
+			args:    []string{"-prefix", "example.com/foo", schema},
+			wantErr: `invalid value "example.com/foo" for flag -prefix: expected KEY=VALUE but got "example.com/foo"`,
+		},
+		{
+			name:    "unknown pointer policy",
+			args:    []string{"-pointers", "sometimes", schema},
+			wantErr: `unknown pointer policy "sometimes"`,
+		},
+		{name: "unknown flag", args: []string{"-bogus", schema}, wantErr: "flag provided but not defined: -bogus"},
+		{
+			name:    "bundle of two schemas",
+			args:    []string{"bundle", schema, schema},
+			wantErr: "usage: jsonschema2go bundle [flags] SCHEMA",
+		},
+		{name: "graph without schemas", args: []string{"graph"}, wantErr: "usage: jsonschema2go graph [flags] SCHEMA..."},
+		{name: "explain without schema", args: []string{"explain"}, wantErr: "usage: jsonschema2go explain"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(context.Background(), tt.args, &stdout)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+			require.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestMain_exitCode(t *testing.T) {
+	_, schema := schemas(t)
+
+	for _, tt := range []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{name: "success", args: []string{"bundle", schema}, wantStdout: `"$defs"`},
+		{
+			name:       "failure",
+			args:       []string{"-prefix", "example.com/foo", schema},
+			wantCode:   1,
+			wantStderr: `expected KEY=VALUE but got "example.com/foo"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			var stdout, stderr bytes.Buffer
+			cmd := exec.Command(os.Args[0], tt.args...)
+			cmd.Env = append(os.Environ(), "JSONSCHEMA2GO_TEST_MAIN=1")
+			cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+			err := cmd.Run()
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code, err = exitErr.ExitCode(), nil
+			}
+			r.NoError(err)
+			r.Equal(tt.wantCode, code, stderr.String())
+			r.Contains(stdout.String(), tt.wantStdout)
+			r.Contains(stderr.String(), tt.wantStderr)
+		})
+	}
+}
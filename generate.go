@@ -2,36 +2,32 @@ package jsonschema2go
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 
+	"github.com/ns1/jsonschema2go/internal/bundle"
 	"github.com/ns1/jsonschema2go/internal/cachingloader"
 	"github.com/ns1/jsonschema2go/internal/crawl"
+	"github.com/ns1/jsonschema2go/internal/explain"
 	"github.com/ns1/jsonschema2go/internal/planning"
 	"github.com/ns1/jsonschema2go/internal/print"
 	"github.com/ns1/jsonschema2go/pkg/gen"
 )
 
 func ExtractName(ctx context.Context, uri string, options ...Option) (string, string, error) {
-	s := &settings{
-		planner: planning.Composite,
-		printer: print.New(nil),
-		typer:   planning.DefaultTyper,
-		loader:  gen.NewLoader(),
-	}
-	for _, o := range options {
-		o(s)
-	}
-	ctx, cncl := context.WithCancel(ctx)
-	defer cncl()
-
-	if s.debug {
-		ctx = gen.SetDebug(ctx)
+	ctx, s, done, err := setup(ctx, options)
+	if err != nil {
+		return "", "", err
 	}
+	defer done()
 
 	u, err := url.Parse(normalizeURI(uri))
 	if err != nil {
@@ -53,6 +49,88 @@ func ExtractName(ctx context.Context, uri string, options ...Option) (string, st
 // Generate generates Go source code from the provided JSON schemas. Options can be provided to customize the
 // output behavior
 func Generate(ctx context.Context, uris []string, options ...Option) error {
+	ctx, s, done, err := setup(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if len(uris) == 0 {
+		return nil
+	}
+
+	normalized, err := loadAll(ctx, s.loader, uris)
+	if err != nil {
+		return err
+	}
+
+	var (
+		grouped map[string][]gen.Plan
+		typer   = s.typer
+	)
+	// renaming colliding types or merging packages may cause further collisions, so retry a limited number of times
+	for attempt := 0; ; attempt++ {
+		grouped, err = crawl.Crawl(ctx, s.planner, s.loader, typer, normalized)
+		var (
+			collisionErr *crawl.TypeCollisionError
+			cycleErr     *crawl.ImportCycleError
+		)
+		switch {
+		case attempt == maxReplanAttempts:
+		case errors.As(err, &collisionErr) && s.rename != nil:
+			gen.Log(ctx).Info("renaming types to resolve name collisions", "collisions", collisionErr)
+			typer = collisionErr.Disambiguate(typer, s.rename)
+			continue
+		case errors.As(err, &cycleErr) && s.mergeCycles:
+			gen.Log(ctx).Info("merging packages to break import cycles", "cycles", cycleErr)
+			typer = cycleErr.Merge(typer)
+			continue
+		}
+		break
+	}
+	if err != nil {
+		return err
+	}
+
+	return print.Print(ctx, s.printer, grouped, s.prefixes, s.files)
+}
+
+// Graph writes the dependency graph of the provided JSON schemas and every schema they reach to w. Each node is a
+// schema along with the Go type generated from it; schemas excluded from generation are included, and edges between
+// types in different Go packages are marked. The graph is written in the Graphviz DOT language unless the
+// OutputFormat is "json".
+func Graph(ctx context.Context, uris []string, w io.Writer, options ...Option) error {
+	ctx, s, done, err := setup(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	normalized, err := loadAll(ctx, s.loader, uris)
+	if err != nil {
+		return err
+	}
+
+	g, err := crawl.CrawlGraph(ctx, s.planner, s.loader, s.typer, normalized)
+	if err != nil {
+		return err
+	}
+
+	switch s.format {
+	case "", "dot":
+		return g.WriteDOT(w)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	}
+	return fmt.Errorf("unsupported output format %q", s.format)
+}
+
+// setup applies the options to the default settings, using a caching loader if none is provided, and returns a
+// context carrying the debug, logging and Go version settings along with a func releasing what was set up
+func setup(ctx context.Context, options []Option) (context.Context, *settings, func(), error) {
 	s := &settings{
 		planner: planning.Composite,
 		printer: print.New(nil),
@@ -62,36 +140,109 @@ func Generate(ctx context.Context, uris []string, options ...Option) error {
 		o(s)
 	}
 
+	ctx, cncl := context.WithCancel(ctx)
+	done := cncl
 	if s.loader == nil {
 		c := cachingloader.NewSimple()
-		defer func() {
-			_ = c.Close()
-		}()
 		s.loader = c
+		done = func() {
+			_ = c.Close()
+			cncl()
+		}
 	}
-	ctx, cncl := context.WithCancel(ctx)
-	defer cncl()
-
 	if s.debug {
 		ctx = gen.SetDebug(ctx)
 	}
-
-	if len(uris) == 0 {
-		return nil
+	if s.logger != nil {
+		ctx = gen.SetLogger(ctx, s.logger)
+	}
+	ctx, err := s.targetGoVersion(ctx)
+	if err != nil {
+		done()
+		return nil, nil, nil, err
 	}
+	return ctx, s, done, nil
+}
 
+// loadAll normalizes and sorts the provided URIs, loading the schemas at them along with every schema they reference
+// so that malformed schemas are reported up front rather than as confusing planning errors
+func loadAll(ctx context.Context, loader gen.Loader, uris []string) ([]string, error) {
 	normalized := make([]string, 0, len(uris))
 	for _, u := range uris {
 		normalized = append(normalized, normalizeURI(u))
 	}
 	sort.Strings(normalized)
 
-	grouped, err := crawl.Crawl(ctx, s.planner, s.loader, s.typer, normalized)
+	urls := make([]*url.URL, 0, len(normalized))
+	for _, n := range normalized {
+		u, err := url.Parse(n)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uri: %w", err)
+		}
+		urls = append(urls, u)
+	}
+	if err := gen.LoadAll(ctx, loader, urls); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Bundle writes a single self-contained JSON schema document to w, holding the schema at the provided URI along with
+// every schema it references under $defs, keyed by their IDs. References are rewritten to point within the document.
+func Bundle(ctx context.Context, uri string, w io.Writer, options ...Option) error {
+	ctx, s, done, err := setup(ctx, options)
 	if err != nil {
 		return err
 	}
+	defer done()
 
-	return print.Print(ctx, s.printer, grouped, s.prefixes)
+	u, err := url.Parse(normalizeURI(uri))
+	if err != nil {
+		return fmt.Errorf("invalid uri: %w", err)
+	}
+
+	doc, err := bundle.Bundle(ctx, s.loader, u)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// Explain writes a description of how the schema at the provided URI is planned to w: the planner which generated its
+// Go type, the planners which declined it and why, and how each field of the type is rendered. The URI's fragment may
+// be a JSON pointer to a subschema, e.g. `foo.json#/properties/bar`. The description is text unless the
+// OutputFormat is "json".
+func Explain(ctx context.Context, uri string, w io.Writer, options ...Option) error {
+	ctx, s, done, err := setup(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	u, err := url.Parse(normalizeURI(uri))
+	if err != nil {
+		return fmt.Errorf("invalid uri: %w", err)
+	}
+
+	e, err := explain.Explain(ctx, s.planner, s.loader, s.typer, u)
+	if err != nil {
+		return err
+	}
+
+	switch s.format {
+	case "", "text":
+		return e.WriteText(w)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(e)
+	}
+	return fmt.Errorf("unsupported output format %q", s.format)
 }
 
 // Option controls the behavior of jsonschema2go, specifying an alternative to the default configuration
@@ -105,6 +256,41 @@ func PrefixMap(pairs ...string) Option {
 	}
 }
 
+// FileName specifies the pattern used to name generated files. A `*` in the pattern is replaced by the base name of the
+// file; by default a single file named values.gen.go is generated per Go package.
+func FileName(pattern string) Option {
+	return func(s *settings) {
+		s.files.FileName = pattern
+	}
+}
+
+// SplitFiles generates one file per top level schema (e.g. bar.gen.go for bar.json) rather than a single file per Go
+// package. When self-contained, the validation error types are rendered once per package.
+func SplitFiles(opt bool) Option {
+	return func(s *settings) {
+		s.files.Split = opt
+	}
+}
+
+// SelfContained renders unexported validation error types into each generated package instead of importing the
+// exported types of github.com/ns1/jsonschema2go/pkg/jsvalidate, so that generated code has no dependency on
+// jsonschema2go.
+func SelfContained(opt bool) Option {
+	return func(s *settings) {
+		s.files.SelfContained = opt
+	}
+}
+
+// CleanStale removes previously generated files which are no longer produced from the directories of generated
+// packages. Only files starting with the jsonschema2go generated code header are removed, and directories of packages
+// which aren't generated are left alone, since other invocations may generate them. So the files of a package whose
+// schemas have all been removed aren't cleaned, and must be removed by hand.
+func CleanStale(opt bool) Option {
+	return func(s *settings) {
+		s.files.Clean = opt
+	}
+}
+
 // Debug enables debug logging
 func Debug(opt bool) Option {
 	return func(s *settings) {
@@ -112,6 +298,78 @@ func Debug(opt bool) Option {
 	}
 }
 
+// Logger sets the logger which receives structured messages about generation, such as which planner produced each
+// type and which files were written. A *slog.Logger may be used directly. If no logger is set, messages are written
+// with the standard log package when Debug is enabled.
+func Logger(l gen.Logger) Option {
+	return func(s *settings) {
+		s.logger = l
+	}
+}
+
+// MergeImportCycles generates the types of Go packages which would otherwise import each other into a single
+// package, the lexically first of those in the cycle. By default, Generate returns an error naming the schemas
+// involved in the cycle.
+func MergeImportCycles(opt bool) Option {
+	return func(s *settings) {
+		s.mergeCycles = opt
+	}
+}
+
+// DisambiguateTypes resolves collisions, where schemas with different IDs would generate Go types with the same name
+// in the same package, by renaming the types of all but the first of the colliding schemas, ordered by ID, with the
+// provided function. n counts the renamed schemas of each collision from 1. By default, Generate returns an error
+// naming the colliding schemas.
+func DisambiguateTypes(rename func(t gen.TypeInfo, schema *gen.Schema, n int) string) Option {
+	return func(s *settings) {
+		s.rename = rename
+	}
+}
+
+// NumberSuffix is a function for DisambiguateTypes which appends n+1 to the colliding type's name, e.g. BarBaz2
+func NumberSuffix(t gen.TypeInfo, schema *gen.Schema, n int) string {
+	return t.Name + strconv.Itoa(n+1)
+}
+
+// OutputFormat selects the format written by Explain, "text" by default, and Graph, "dot" by default; both also support
+// "json"
+func OutputFormat(format string) Option {
+	return func(s *settings) {
+		s.format = format
+	}
+}
+
+// TitleNames names the types generated from schemas with a title after the title, passed through the same rules as
+// property names, and adds the title to their doc comments. An explicit x-jsonschema2go gopath takes precedence over
+// the title, which takes precedence over the name derived from the schema's ID. The Go path is unaffected.
+func TitleNames(opt bool) Option {
+	return func(s *settings) {
+		s.typer.Titles = opt
+	}
+}
+
+// Pointers sets the policy for how the string, integer, number and boolean fields of structs are typed: as pointers
+// (gen.PointersAlways, the default), as pointers only when optional (gen.PointersOptional), or as jsoptional.Optional
+// when optional (gen.PointersGeneric). Required fields are values under the latter two, so their absence is reported
+// when decoding rather than by Validate. Under gen.PointersNullable, these and object fields are jsoptional.Nullable,
+// so that null is distinguished from an absent value and rejected unless the field's schema permits it. A schema may
+// override the policy for its fields, or a field for itself, with x-jsonschema2go pointers. The generic and nullable
+// policies require a GoVersion of 1.18 or later, and can't be SelfContained since they use jsoptional.
+func Pointers(policy gen.PointerPolicy) Option {
+	return func(s *settings) {
+		s.typer.Pointers = policy
+	}
+}
+
+// GoVersion sets the Go version targeted by the generated code, e.g. "1.18". By default code compiles with Go 1.13.
+// From Go 1.18, the generated code uses `any`, and the generic and nullable Pointers policies may be set. From Go
+// 1.23, the entries of maps are validated in the order of their sorted keys, using the slices and maps packages.
+func GoVersion(version string) Option {
+	return func(s *settings) {
+		s.goVersion = version
+	}
+}
+
 // CustomTypeFunc registers a custom function for generating TypeInfo from a Schema.
 func CustomTypeFunc(typeFunc func(schema *gen.Schema) gen.TypeInfo) Option {
 	return func(s *settings) {
@@ -156,13 +414,22 @@ func CustomTemplate(tmpl *template.Template) Option {
 	}
 }
 
-// CustomInitialisms registers a custom list of initialisms used in name generation
+// CustomInitialisms replaces the initialisms used in name generation with the provided list, plus ID and HTTP. Use
+// AdditionalInitialisms to extend the defaults instead.
 func CustomInitialisms(names ...string) Option {
 	return func(s *settings) {
 		s.typer.Namer = planning.NewNamer(append(names, "id", "http"))
 	}
 }
 
+// AdditionalInitialisms adds initialisms used in name generation to those already known, which by default are the
+// golint common initialisms such as URL and JSON
+func AdditionalInitialisms(names ...string) Option {
+	return func(s *settings) {
+		s.typer.Namer = s.typer.Namer.With(names...)
+	}
+}
+
 func prefixPairs(pairs []string) [][2]string {
 	if len(pairs)%2 != 0 {
 		panic("must be even list of prefixes")
@@ -175,18 +442,56 @@ func prefixPairs(pairs []string) [][2]string {
 }
 
 type settings struct {
-	prefixes [][2]string
-	typer    planning.Typer
-	planner  gen.Planner
-	printer  print.Printer
-	loader   gen.Loader
-	debug    bool
+	prefixes    [][2]string
+	typer       planning.Typer
+	planner     gen.Planner
+	printer     print.Printer
+	files       print.Config
+	loader      gen.Loader
+	debug       bool
+	logger      gen.Logger
+	format      string
+	mergeCycles bool
+	rename      func(t gen.TypeInfo, schema *gen.Schema, n int) string
+	goVersion   string
+}
+
+// targetGoVersion sets the targeted Go version in the context, rejecting pointer policies which need generics unless
+// they're available, or which need jsoptional when self-contained
+func (s *settings) targetGoVersion(ctx context.Context) (context.Context, error) {
+	var v gen.GoVersion
+	if s.goVersion != "" {
+		var err error
+		if v, err = gen.ParseGoVersion(s.goVersion); err != nil {
+			return nil, err
+		}
+	}
+	if s.typer.Pointers.Generic() && !v.Generics() {
+		return nil, fmt.Errorf("pointer policy %q requires targeting Go 1.18 or later; set GoVersion", s.typer.Pointers)
+	}
+	if s.files.SelfContained {
+		if s.typer.Pointers.Generic() {
+			return nil, fmt.Errorf("pointer policy %q uses jsoptional, so can't be self-contained", s.typer.Pointers)
+		}
+		ctx = gen.SetSelfContained(ctx)
+	}
+	if s.goVersion == "" {
+		return ctx, nil
+	}
+	return gen.SetGoVersion(ctx, v), nil
 }
 
+// maxReplanAttempts limits how many times types are renamed or packages merged before giving up
+const maxReplanAttempts = 10
+
 func normalizeURI(uriOrFile string) string {
 	if u, err := url.Parse(uriOrFile); err == nil && u.Scheme != "" {
 		return uriOrFile
 	}
-	p, _ := filepath.Abs(uriOrFile)
-	return "file:" + p
+	p, fragment := uriOrFile, ""
+	if i := strings.IndexByte(p, '#'); i >= 0 {
+		p, fragment = p[:i], p[i:]
+	}
+	p, _ = filepath.Abs(p)
+	return "file:" + p + fragment
 }
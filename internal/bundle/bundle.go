@@ -0,0 +1,194 @@
+// Package bundle combines a schema and every schema it references into a single self-contained document.
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// DefsKey is the keyword under which referenced schemas are placed
+const DefsKey = "$defs"
+
+// Bundle loads the schema at the provided URL along with every schema it references, directly or transitively. The
+// referenced schemas are placed under $defs in the returned document, keyed by their IDs, and all references are
+// rewritten to point within the document. Referenced schemas and the subschemas of every document lose their own IDs,
+// which would otherwise change how the rewritten references resolve. Distinct documents sharing an ID can't both be placed under it, so are an error.
+func Bundle(ctx context.Context, loader gen.Loader, u *url.URL) (map[string]interface{}, error) {
+	b := &bundler{loader: loader, keys: make(map[string]string), srcs: make(map[string]string)}
+
+	root, err := b.load(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	b.keys[docURL(root.Src)] = ""
+	if root.ID != nil {
+		b.srcs[strings.TrimSuffix(root.ID.String(), "#")] = docURL(root.Src)
+	}
+
+	doc, err := b.rewrite(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]interface{})
+	for len(b.pending) > 0 {
+		s := b.pending[0]
+		b.pending = b.pending[1:]
+
+		def, err := b.rewrite(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range []string{"$id", "id", "$schema"} {
+			delete(def, k)
+		}
+		defs[b.keys[docURL(s.Src)]] = def
+	}
+	if len(defs) > 0 {
+		if existing, ok := doc[DefsKey].(map[string]interface{}); ok {
+			for k, v := range existing {
+				if _, ok := defs[k]; !ok {
+					defs[k] = v
+				}
+			}
+		}
+		doc[DefsKey] = defs
+	}
+	return doc, nil
+}
+
+type bundler struct {
+	loader  gen.Loader
+	keys    map[string]string // the $defs key of every known document, by the URL it was loaded from
+	srcs    map[string]string // the URL every known document was loaded from, by its ID
+	pending []*gen.Schema
+}
+
+func (b *bundler) load(ctx context.Context, u *url.URL) (*gen.Schema, error) {
+	s, err := b.loader.Load(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %v: %w", u, err)
+	}
+	return s, nil
+}
+
+// rewrite returns the schema as generic JSON with its references rewritten, queueing any newly referenced documents
+func (b *bundler) rewrite(ctx context.Context, s *gen.Schema) (map[string]interface{}, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %v: %w", s, err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %v: %w", s, err)
+	}
+	if err := b.walk(ctx, s.Src, doc, false, false); err != nil {
+		return nil, fmt.Errorf("unable to bundle %v: %w", s, err)
+	}
+	return doc, nil
+}
+
+// walk rewrites the references within v, which is a map from names to schemas, such as properties, if schemaMap is
+// set, or else part of a schema. The IDs of nested schemas are removed.
+func (b *bundler) walk(ctx context.Context, src *url.URL, v interface{}, schemaMap, nested bool) error {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, e := range v {
+			if err := b.walk(ctx, src, e, false, true); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		if schemaMap {
+			// the keys are names, which may coincide with keywords
+			for _, e := range v {
+				if err := b.walk(ctx, src, e, false, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if nested {
+			// references are resolved against the document, so an ID would only misdirect them once bundled
+			delete(v, "$id")
+			delete(v, "id")
+		}
+		if ref, ok := v["$ref"].(string); ok {
+			local, err := b.localRef(ctx, src, ref)
+			if err != nil {
+				return err
+			}
+			v["$ref"] = local
+		}
+		for k, e := range v {
+			switch k {
+			case "enum", "const", "default", "examples": // values rather than schemas
+				continue
+			}
+			if err := b.walk(ctx, src, e, schemaMaps[k], true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaMaps are the keywords whose values map names to schemas
+var schemaMaps = map[string]bool{
+	"properties":        true,
+	"patternProperties": true,
+	"definitions":       true,
+	DefsKey:             true,
+	"dependencies":      true,
+}
+
+// localRef returns the pointer within the bundle corresponding to a reference from the document loaded from src
+func (b *bundler) localRef(ctx context.Context, src *url.URL, ref string) (string, error) {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+	target := src.ResolveReference(parsed)
+
+	key, ok := b.keys[docURL(target)]
+	if !ok {
+		s, err := b.load(ctx, target)
+		if err != nil {
+			return "", err
+		}
+		key = strings.TrimSuffix(s.ID.String(), "#")
+		switch other, ok := b.srcs[key]; {
+		case !ok:
+			b.srcs[key] = docURL(s.Src)
+			b.pending = append(b.pending, s)
+			gen.Log(ctx).Debug("bundling schema", "url", target, "key", key)
+		case other == docURL(s.Src): // the same document, referenced by another URL
+			if k, ok := b.keys[other]; ok {
+				key = k
+			}
+		default:
+			return "", fmt.Errorf("%v and %v have the same ID %s", other, docURL(s.Src), key)
+		}
+		b.keys[docURL(target)] = key
+	}
+
+	pointer := ""
+	if key != "" {
+		pointer = "/" + DefsKey + "/" + pointerToken(key)
+	}
+	return "#" + (&url.URL{Fragment: pointer + target.Fragment}).EscapedFragment(), nil
+}
+
+// docURL returns the normalized URL of the document containing the resource at u
+func docURL(u *url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host, Path: u.Path, RawQuery: u.RawQuery}).String()
+}
+
+func pointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
@@ -0,0 +1,114 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/ns1/jsonschema2go/pkg/gen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	r := require.New(t)
+
+	p, err := filepath.Abs("testdata/simple/root.json")
+	r.NoError(err)
+
+	u, err := url.Parse("file:" + p) // as normalized by jsonschema2go.Bundle
+	r.NoError(err)
+
+	doc, err := Bundle(context.Background(), gen.NewLoader(), u)
+	r.NoError(err)
+
+	got, err := json.Marshal(doc)
+	r.NoError(err)
+	r.JSONEq(`{
+		"$id": "https://example.com/schemas/root.json",
+		"type": "object",
+		"properties": {
+			"foo": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1foo.json"},
+			"bar": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1sub~1bar.json/definitions/bar"},
+			"self": {"$ref": "#/definitions/local"},
+			"default": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1sub~1bar.json/definitions/baz"},
+			"enum": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1foo.json"}
+		},
+		"definitions": {
+			"local": {"type": "string", "default": {"$ref": "not a reference"}}
+		},
+		"$defs": {
+			"https://example.com/schemas/foo.json": {
+				"type": "array",
+				"items": {"$ref": "#"}
+			},
+			"https://example.com/schemas/sub/bar.json": {
+				"definitions": {
+					"bar": {
+						"type": "object",
+						"properties": {
+							"baz": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1sub~1bar.json/definitions/baz"},
+							"foo": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1foo.json"}
+						}
+					},
+					"baz": {"type": "integer"}
+				}
+			}
+		}
+	}`, string(got))
+}
+
+func TestBundle_nestedIDs(t *testing.T) {
+	r := require.New(t)
+
+	p, err := filepath.Abs("testdata/nested/root.json")
+	r.NoError(err)
+
+	u, err := url.Parse("file:" + p)
+	r.NoError(err)
+
+	doc, err := Bundle(context.Background(), gen.NewLoader(), u)
+	r.NoError(err)
+
+	got, err := json.Marshal(doc)
+	r.NoError(err)
+	r.JSONEq(`{
+		"$id": "https://example.com/schemas/root.json",
+		"type": "object",
+		"properties": {
+			"item": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string"},
+					"tag": {"$ref": "#/$defs/https:~1~1example.com~1schemas~1tag.json"}
+				}
+			}
+		},
+		"definitions": {
+			"legacy": {"items": [{"$ref": "#/definitions/legacy"}]}
+		},
+		"$defs": {
+			"https://example.com/schemas/tag.json": {
+				"type": "object",
+				"properties": {
+					"owner": {"$ref": "#/properties/item"}
+				}
+			}
+		}
+	}`, string(got))
+}
+
+func TestBundle_duplicateID(t *testing.T) {
+	r := require.New(t)
+
+	p, err := filepath.Abs("testdata/duplicate/root.json")
+	r.NoError(err)
+
+	u, err := url.Parse("file:" + p)
+	r.NoError(err)
+
+	_, err = Bundle(context.Background(), gen.NewLoader(), u)
+	r.Error(err)
+	r.Contains(err.Error(), "have the same ID https://example.com/schemas/shared.json")
+}
@@ -3,13 +3,12 @@ package cachingloader
 import (
 	"context"
 	"github.com/ns1/jsonschema2go/pkg/gen"
-	"log"
 	"net/url"
 	"sync"
 )
 
-// New returns a new thread safe loader which caches requests and can handle either file system or http URIs. If the
-// debug bool flag is set true, messages will be logged concerning every served request.
+// New returns a new thread safe loader which caches requests and can handle either file system or http URIs. Cache
+// misses are logged at debug level to the logger in the context.
 func NewSimple() gen.Loader {
 	return &loader{
 		cache:  make(map[string]*gen.Schema),
@@ -40,9 +39,7 @@ func (l *loader) Load(ctx context.Context, u *url.URL) (*gen.Schema, error) {
 		return v, nil
 	}
 
-	if gen.IsDebug(ctx) {
-		log.Printf("cache miss -- requesting %v", u)
-	}
+	gen.Log(ctx).Debug("cache miss", "url", u)
 	schema, err := l.loader.Load(ctx, u)
 	if err != nil {
 		return nil, err
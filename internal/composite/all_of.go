@@ -28,7 +28,7 @@ func PlanAllOfObject(ctx context.Context, helper gen.Helper, schema *gen.Schema)
 	// we've matched
 
 	s := &StructPlan{TypeInfo: tInfo, ID: schema.ID}
-	s.Comment = schema.Annotations.GetString("description")
+	s.Comment = helper.Comment(schema)
 
 	fields, err := deriveStructFields(ctx, helper, schema)
 	if err != nil {
@@ -63,6 +63,18 @@ func PlanAllOfObject(ctx context.Context, helper gen.Helper, schema *gen.Schema)
 
 		// parent may still check whether required field present
 
+		for _, f := range fields {
+			if !f.Boxed.Unknown() || (f.Required && f.primitiveValue()) {
+				// the embedded type's MarshalJSON or UnmarshalJSON would be promoted, encoding only its own fields
+				return nil, fmt.Errorf(
+					"allOf schema %v: property %q of an embedded type can't be boxed or a required value; "+
+						"set promoteFields or use another pointer policy",
+					subSchema.ID,
+					f.JSONName,
+				)
+			}
+		}
+
 		tInfo := helper.TypeInfoHinted(subSchema, gen.JSONObject)
 		// this is a named type, add an embedded field for the subschema type
 		s.Fields = append(s.Fields, StructField{Type: tInfo, FieldValidators: []validator.Validator{validator.SubschemaValidator}})
@@ -82,6 +94,8 @@ func PlanAllOfObject(ctx context.Context, helper gen.Helper, schema *gen.Schema)
 		v.Required = true
 		s.SubRequired = append(s.SubRequired, v)
 	}
+	s.addBoxedTrait()
+	s.addRequiredKeysTrait()
 
 	return s, nil
 }
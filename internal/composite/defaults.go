@@ -0,0 +1,261 @@
+package composite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ns1/jsonschema2go/internal/validator"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// checkDefault returns an error if the default value of a field with the provided schema might not decode into the
+// field's type, so that it fails generation rather than every call of ApplyDefaults. It mirrors how generated types
+// decode: values must be of the schema's type, objects must have their required properties, and the values of unions
+// must match exactly one of their schemas. Keywords only checked by Validate are ignored, though decoding a oneOf of
+// objects checks them to choose its match, so ApplyDefaults may still return an error for such a default.
+func checkDefault(ctx context.Context, helper gen.Helper, schema *gen.Schema, def json.RawMessage) error {
+	dec := json.NewDecoder(bytes.NewReader(def))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("unable to decode default: %w", err)
+	}
+	return (&defaultChecker{helper: helper}).check(ctx, schema, v, "")
+}
+
+type defaultChecker struct {
+	helper gen.Helper
+	depth  int // guards against recursive schemas whose values never end, which can't be defaults anyway
+}
+
+// maxDefaultDepth limits how deeply nested a default value is checked
+const maxDefaultDepth = 64
+
+// check returns an error if the value, found at the JSON pointer within the default, doesn't decode as the schema
+func (c *defaultChecker) check(ctx context.Context, schema *gen.Schema, v interface{}, pointer string) error {
+	if c.depth++; c.depth > maxDefaultDepth {
+		return fmt.Errorf("%s: default is nested too deeply to check", location(pointer))
+	}
+	defer func() { c.depth-- }()
+
+	if err := checkType(schema, v, pointer); err != nil {
+		return err
+	}
+	if v == nil && len(schema.OneOf) == 0 {
+		return nil
+	}
+
+	for _, sub := range schema.AllOf {
+		s, err := sub.Resolve(ctx, schema, c.helper)
+		if err != nil {
+			return err
+		}
+		if err := c.check(ctx, s, v, pointer); err != nil {
+			return err
+		}
+	}
+	if len(schema.OneOf) > 0 {
+		if err := c.checkOneOf(ctx, schema, v, pointer); err != nil {
+			return err
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return c.checkObject(ctx, schema, v, pointer)
+	case []interface{}:
+		return c.checkArray(ctx, schema, v, pointer)
+	}
+	return nil
+}
+
+// checkType returns an error if the value isn't of one of the types listed or implied by the schema, if any. A null
+// value is only checked against listed types, since null decodes as no value otherwise.
+func checkType(schema *gen.Schema, v interface{}, pointer string) error {
+	types := gen.TypeField{schema.ChooseType()}
+	switch {
+	case schema.Type != nil && len(*schema.Type) > 0:
+		types = *schema.Type
+	case v == nil || types[0] == gen.JSONUnknown:
+		return nil
+	}
+	if v == nil && schema.Nullable {
+		return nil
+	}
+	for _, t := range types {
+		if isOfType(v, t) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %s isn't of type %s", location(pointer), describe(v), describe(gen.TypeField(types)))
+}
+
+func containsType(types gen.TypeField, t gen.JSONType) bool {
+	for _, u := range types {
+		if u == t {
+			return true
+		}
+	}
+	return false
+}
+
+func isOfType(v interface{}, t gen.JSONType) bool {
+	switch v := v.(type) {
+	case nil:
+		return t == gen.JSONNull
+	case bool:
+		return t == gen.JSONBoolean
+	case string:
+		return t == gen.JSONString
+	case json.Number:
+		if t == gen.JSONInteger {
+			_, err := v.Int64()
+			return err == nil
+		}
+		return t == gen.JSONNumber
+	case []interface{}:
+		return t == gen.JSONArray
+	case map[string]interface{}:
+		return t == gen.JSONObject
+	}
+	return false
+}
+
+// checkOneOf returns an error unless the value is decoded as exactly one of the schemas of the oneOf, chosen by the
+// discriminator if there is one
+func (c *defaultChecker) checkOneOf(ctx context.Context, schema *gen.Schema, v interface{}, pointer string) error {
+	var branches []*gen.Schema
+	for _, sub := range schema.OneOf {
+		s, err := sub.Resolve(ctx, schema, c.helper)
+		if err != nil {
+			return err
+		}
+		branches = append(branches, s)
+	}
+
+	if discrim := schema.Config.Discriminator; discrim.IsSet() {
+		obj, _ := v.(map[string]interface{})
+		value, _ := obj[discrim.PropertyName].(string)
+		name, ok := discrim.Mapping[value]
+		if !ok {
+			if name, ok = discrim.Mapping["*"]; !ok {
+				return fmt.Errorf("%s: unknown discriminator %q", location(pointer), value)
+			}
+		}
+		for _, b := range branches {
+			if t, err := c.helper.TypeInfo(b); err == nil && t.Name == name {
+				return c.check(ctx, b, v, pointer)
+			}
+		}
+		return fmt.Errorf("%s: no schema for discriminator %q", location(pointer), value)
+	}
+
+	if v == nil {
+		// a union holds null if any of its schemas permits it
+		for _, b := range branches {
+			if b.Nullable || (b.Type != nil && containsType(*b.Type, gen.JSONNull)) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: null matches none of the oneOf schemas", location(pointer))
+	}
+
+	var (
+		matches  int
+		failures []string
+	)
+	for _, b := range branches {
+		if err := c.check(ctx, b, v, pointer); err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		matches++
+	}
+	switch {
+	case matches == 0:
+		return fmt.Errorf("%s: matches none of the oneOf schemas: %s", location(pointer), strings.Join(failures, "; "))
+	case matches > 1:
+		return fmt.Errorf("%s: matches more than one of the oneOf schemas", location(pointer))
+	}
+	return nil
+}
+
+func (c *defaultChecker) checkObject(
+	ctx context.Context,
+	schema *gen.Schema,
+	obj map[string]interface{},
+	pointer string,
+) error {
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			return fmt.Errorf("%s: required property %q is missing", location(pointer), name)
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var sub *gen.RefOrSchema
+		if p, ok := schema.Properties[k]; ok {
+			sub = p
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			sub = schema.AdditionalProperties.Schema
+		}
+		if sub == nil {
+			continue
+		}
+		s, err := sub.Resolve(ctx, schema, c.helper)
+		if err != nil {
+			return err
+		}
+		if err := c.check(ctx, s, obj[k], pointer+"/"+validator.PointerToken(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *defaultChecker) checkArray(ctx context.Context, schema *gen.Schema, arr []interface{}, pointer string) error {
+	if schema.Items == nil {
+		return nil
+	}
+	for i, item := range arr {
+		sub := schema.Items.Items
+		switch {
+		case i < len(schema.Items.TupleFields):
+			sub = schema.Items.TupleFields[i]
+		case len(schema.Items.TupleFields) > 0 && schema.AdditionalItems != nil:
+			sub = schema.AdditionalItems.Schema
+		}
+		if sub == nil {
+			continue
+		}
+		s, err := sub.Resolve(ctx, schema, c.helper)
+		if err != nil {
+			return err
+		}
+		if err := c.check(ctx, s, item, fmt.Sprintf("%s/%d", pointer, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func location(pointer string) string {
+	if pointer == "" {
+		return "default"
+	}
+	return "default at " + pointer
+}
+
+func describe(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -36,7 +36,7 @@ func PlanDiscriminatedOneOfObject(ctx context.Context, helper gen.Helper, schema
 
 	typeMapping := make(map[string]gen.TypeInfo)
 	s := &StructPlan{TypeInfo: tInfo, ID: schema.ID}
-	s.Comment = schema.Annotations.GetString("description")
+	s.Comment = helper.Comment(schema)
 	for _, subSchema := range schemas {
 		tInfo, err := helper.TypeInfo(subSchema)
 		if err != nil {
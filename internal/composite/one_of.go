@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"unicode"
 
 	"github.com/ns1/jsonschema2go/internal/validator"
 	"github.com/ns1/jsonschema2go/pkg/gen"
@@ -34,21 +35,146 @@ func PlanOneOfDiffTypes(ctx context.Context, helper gen.Helper, schema *gen.Sche
 		}
 		seen[typ] = true
 	}
+	return planUnion(ctx, helper, schema, "oneOf", schemas, branchLocations(schema.OneOf))
+}
+
+// PlanOneOfObjects attempts to generate a plan for a schema which is a `oneOf` with multiple object schemas and no
+// discriminator. A value is decoded into each of the objects in turn and validated, and must match exactly one of
+// them. The match is held as a sealed interface implemented by the objects, which must be in the same Go package.
+func PlanOneOfObjects(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan, error) {
+	schemas, err := objectBranches(ctx, helper, schema)
+	if err != nil {
+		return nil, err
+	}
+	tInfo := helper.TypeInfoHinted(schema, gen.JSONObject)
+
+	s := &StructPlan{TypeInfo: tInfo, ID: schema.ID}
+	s.Comment = helper.Comment(schema)
+
+	trait := &sealedOneOfTrait{
+		Interface: gen.TypeInfo{GoPath: tInfo.GoPath, Name: tInfo.Name + "Value"},
+		Locations: branchLocations(schema.OneOf),
+	}
+	for _, subSchema := range schemas {
+		info, err := helper.TypeInfo(subSchema)
+		if err != nil {
+			return nil, err
+		}
+		trait.Branches = append(trait.Branches, info)
+	}
+	if err := helper.Dep(ctx, schemas...); err != nil {
+		return nil, err
+	}
+
+	s.Fields = []StructField{{
+		Name:            "Value",
+		Type:            trait.Interface,
+		Sealed:          true,
+		FieldValidators: []validator.Validator{validator.SubschemaValidator},
+	}}
+	s.Traits = []Trait{trait}
+	return s, nil
+}
+
+// objectBranches returns the schemas of a `oneOf` which may be planned by PlanOneOfObjects, or ErrContinue if it may
+// not be
+func objectBranches(ctx context.Context, helper gen.Helper, schema *gen.Schema) ([]*gen.Schema, error) {
+	if schema.Config.Discriminator.IsSet() {
+		return nil, fmt.Errorf("discriminator is set: %w", gen.ErrContinue)
+	}
+	if len(schema.OneOf) < 2 {
+		return nil, fmt.Errorf("fewer than two oneOf schemas: %w", gen.ErrContinue)
+	}
+	tInfo := helper.TypeInfoHinted(schema, gen.JSONObject)
+	if tInfo.Unknown() {
+		return nil, fmt.Errorf("schema type is unknown: %w", gen.ErrContinue)
+	}
+	_, schemas, err := loadSchemaList(ctx, helper, schema, schema.OneOf)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[gen.TypeInfo]bool)
+	for _, s := range schemas {
+		if typ, err := helper.DetectSimpleType(ctx, s); err != nil || typ != gen.JSONObject {
+			return nil, fmt.Errorf("%v is not an object: %w", s, gen.ErrContinue)
+		}
+		info, err := helper.TypeInfo(s)
+		if err != nil || info.BuiltIn() {
+			return nil, fmt.Errorf("%v is not a named type: %w", s, gen.ErrContinue)
+		}
+		if info.GoPath != tInfo.GoPath {
+			// methods sealing the interface can only be declared in the package of the type
+			return nil, fmt.Errorf("%v is not in the package %v: %w", info, tInfo.GoPath, gen.ErrContinue)
+		}
+		if seen[info] {
+			return nil, fmt.Errorf("type %v seen too many times: %w", info, gen.ErrContinue)
+		}
+		seen[info] = true
+	}
+	return schemas, nil
+}
+
+// PlanTypeUnion attempts to generate a plan for a schema whose `type` lists more than one type other than null, such
+// as ["integer", "string"]. It is planned like a `oneOf` of different types, with a branch per listed type which
+// carries the schema's other keywords. Since integers and numbers can't be told apart, a union of both is a number.
+func PlanTypeUnion(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan, error) {
+	if schema.Type == nil || !schema.Type.Union() {
+		return nil, fmt.Errorf("not a union of types: %w", gen.ErrContinue)
+	}
+	branches := typeBranches(helper.TypeInfoHinted(schema, gen.JSONObject), schema)
+	// each branch carries the schema's keywords, so is located at the schema itself
+	return planUnion(ctx, helper, schema, "type", branches, make([]string, len(branches)))
+}
+
+// typeBranches returns a schema per type listed in the schema's `type`. Each shares the schema's ID and location, but
+// is a variant named after the union and the type's index, e.g. BarType0.
+func typeBranches(union gen.TypeInfo, schema *gen.Schema) (branches []*gen.Schema) {
+	types := *schema.Type
+	hasNumber := false
+	for _, t := range types {
+		hasNumber = hasNumber || t == gen.JSONNumber
+	}
+	for i, t := range types {
+		if t == gen.JSONInteger && hasNumber {
+			continue
+		}
+		b := *schema
+		b.Type = &gen.TypeField{t}
+		b.Variant = fmt.Sprintf("type %d", i)
+		// the branches are distinct types, so mustn't share a name with the union
+		b.Config.GoPath, b.Config.TypeName = "", fmt.Sprintf("%sType%d", union.Name, i)
+		b.Annotations = nil
+		branches = append(branches, &b)
+	}
+	return
+}
+
+// planUnion plans a struct holding a value of any of the schemas, each of which must be of a different JSON type. The
+// keyword is the one listing the schemas, which a value of any other type fails, and the locations are JSON pointers to
+// the schemas relative to the union's.
+func planUnion(
+	ctx context.Context,
+	helper gen.Helper,
+	schema *gen.Schema,
+	keyword string,
+	schemas []*gen.Schema,
+	locations []string,
+) (gen.Plan, error) {
 	tInfo := helper.TypeInfoHinted(schema, gen.JSONObject)
 	if tInfo.Unknown() {
 		return nil, fmt.Errorf("schema type is unknown: %w", gen.ErrContinue)
 	}
 
 	s := &StructPlan{TypeInfo: tInfo, ID: schema.ID}
-	s.Comment = schema.Annotations.GetString("description")
+	s.Comment = helper.Comment(schema)
 
 	f := StructField{Name: "Value", Type: gen.TypeInfo{Name: "interface{}"}}
 
 	var (
-		trait            marshalOneOfTrait
+		trait            = marshalOneOfTrait{Keyword: keyword}
 		checkedSubSchema bool
 	)
-	for _, subSchema := range schemas {
+	for i, subSchema := range schemas {
 		info, err := helper.TypeInfo(subSchema)
 		if err != nil {
 			return nil, err
@@ -71,9 +197,9 @@ func PlanOneOfDiffTypes(ctx context.Context, helper gen.Helper, schema *gen.Sche
 
 		switch jType {
 		case gen.JSONObject:
-			trait.Object = info
+			trait.Object, trait.ObjectLocation = info, locations[i]
 		case gen.JSONArray:
-			trait.Array = info
+			trait.Array, trait.ArrayLocation = info, locations[i]
 		case gen.JSONString:
 			trait.Primitives = append(trait.Primitives, "string")
 		case gen.JSONNumber:
@@ -86,26 +212,112 @@ func PlanOneOfDiffTypes(ctx context.Context, helper gen.Helper, schema *gen.Sche
 			trait.Nil = true
 		}
 
-		for _, v := range validator.Validators(subSchema) {
+		for _, v := range validator.Validators(ctx, subSchema) {
 			if v.Name == validator.SubschemaValidator.Name {
+				// the value is validated against its branch's schema by type, whichever branch declares it
 				if checkedSubSchema {
 					continue
 				}
 				checkedSubSchema = true
 			}
+			v.Location = locations[i]
 			f.FieldValidators = append(f.FieldValidators, v)
 		}
 	}
+	// the methods and constructors of each branch are named after it, e.g. AsString and BarFromString, so a Baz
+	// object named String mustn't share a union with a string
+	seen := make(map[string]gen.TypeInfo)
+	for _, b := range trait.Branches() {
+		if other, ok := seen[b.Name]; ok {
+			return nil, fmt.Errorf(
+				"branches of types %s and %s would both declare As%s, %sFrom%s and %sKind%s",
+				other.Name, b.Type.Name, b.Name, tInfo.Name, b.Name, tInfo.Name, b.Name,
+			)
+		}
+		seen[b.Name] = b.Type
+	}
 	s.Traits = []Trait{trait}
 	s.Fields = []StructField{f}
 	return s, nil
 }
 
+// branchLocations returns JSON pointers to the schemas of a `oneOf`, relative to the schema listing them, following
+// any $ref
+func branchLocations(schemas []*gen.RefOrSchema) []string {
+	locations := make([]string, 0, len(schemas))
+	for i, s := range schemas {
+		l := fmt.Sprintf("/oneOf/%d", i)
+		if s.IsRef() {
+			l += "/$ref"
+		}
+		locations = append(locations, l)
+	}
+	return locations
+}
+
 type marshalOneOfTrait struct {
-	Object     gen.TypeInfo
-	Array      gen.TypeInfo
-	Primitives []string
-	Nil        bool
+	Object         gen.TypeInfo
+	ObjectLocation string // a JSON pointer to the schema of the object, relative to the union's
+	Array          gen.TypeInfo
+	ArrayLocation  string // a JSON pointer to the schema of the array, relative to the union's
+	Primitives     []string
+	Nil            bool
+	Keyword        string // the keyword listing the types of value held, "oneOf" or "type"
+}
+
+// unionBranch is a type of value which a union may hold
+type unionBranch struct {
+	Name     string // distinguishes the branch's methods, e.g. "String" for AsString
+	Type     gen.TypeInfo
+	Location string // a JSON pointer to the branch's schema relative to the union's, if it's validated as a whole
+}
+
+// Branches returns the types of value the union may hold, other than null, in the order they're decoded. The branches
+// of a `oneOf` are named after their Go types, e.g. Baz or Int64, but as those of a union of types are variants named
+// after the union, e.g. BarType0, they're named after their JSON types instead, e.g. Object or Integer.
+func (m marshalOneOfTrait) Branches() (branches []unionBranch) {
+	byType := m.Keyword == "type"
+	if m.Object.Name != "" {
+		name := m.Object.Name
+		if byType {
+			name = "Object"
+		}
+		branches = append(branches, unionBranch{Name: name, Type: m.Object, Location: m.ObjectLocation})
+	}
+	if m.Array.Name != "" {
+		name := m.Array.Name
+		if byType {
+			name = "Array"
+		}
+		branches = append(branches, unionBranch{Name: name, Type: m.Array, Location: m.ArrayLocation})
+	}
+	for _, p := range m.Primitives {
+		name := primitiveJSONNames[p]
+		if !byType {
+			r := []rune(p)
+			r[0] = unicode.ToUpper(r[0])
+			name = string(r)
+		}
+		branches = append(branches, unionBranch{Name: name, Type: gen.TypeInfo{Name: p}})
+	}
+	return
+}
+
+// primitiveJSONNames name the branches of a union of types holding primitives after their JSON types
+var primitiveJSONNames = map[string]string{
+	"string":  "String",
+	"float64": "Number",
+	"int64":   "Integer",
+	"bool":    "Boolean",
+}
+
+// decls returns the kinds and constructors declared for a union with the provided name
+func (m marshalOneOfTrait) decls(typeName string) []string {
+	decls := []string{typeName + "Kind", typeName + "KindNull"}
+	for _, b := range m.Branches() {
+		decls = append(decls, typeName+"Kind"+b.Name, typeName+"From"+b.Name)
+	}
+	return decls
 }
 
 func (m marshalOneOfTrait) Template() string {
@@ -122,6 +334,37 @@ func (m marshalOneOfTrait) Deps() []gen.TypeInfo {
 	}
 }
 
+// sealedOneOfTrait declares the interface implemented by the objects of a union planned by PlanOneOfObjects, and
+// decodes a value into whichever of them it matches
+type sealedOneOfTrait struct {
+	Interface gen.TypeInfo
+	Branches  []gen.TypeInfo
+	Locations []string // JSON pointers to the schemas of the branches, relative to the union's
+}
+
+// decls returns the name of the interface sealing the union
+func (s *sealedOneOfTrait) decls(string) []string {
+	return []string{s.Interface.Name}
+}
+
+func (s *sealedOneOfTrait) Template() string {
+	return "sealedOneOf"
+}
+
+// Seal returns the name of the unexported method which seals the interface
+func (s *sealedOneOfTrait) Seal() string {
+	return "is" + s.Interface.Name
+}
+
+func (s *sealedOneOfTrait) Deps() []gen.TypeInfo {
+	return []gen.TypeInfo{
+		{GoPath: "encoding/json", Name: "Marshal"},
+		{GoPath: "fmt", Name: "Errorf"},
+		{GoPath: "bytes", Name: "NewReader"},
+		{GoPath: "strings", Name: "Join"},
+	}
+}
+
 type discriminatorMarshalTrait struct {
 	StructField
 	types map[string]gen.TypeInfo
@@ -165,3 +408,8 @@ func (d *discriminatorMarshalTrait) Deps() []gen.TypeInfo {
 type Trait interface {
 	Template() string
 }
+
+// declsTrait is implemented by traits which declare package level identifiers besides the struct's type
+type declsTrait interface {
+	decls(typeName string) []string
+}
@@ -3,9 +3,13 @@ package composite
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/ns1/jsonschema2go/internal/validator"
@@ -20,7 +24,21 @@ type StructField struct {
 	Type            gen.TypeInfo
 	Tag             string
 	Required        bool
+	Ref             bool            // whether the field's schema is a $ref
+	Default         json.RawMessage // the field's default value, if any
 	FieldValidators []validator.Validator
+	Boxed           gen.TypeInfo // the type of the value held by Type, if Type is a box such as jsoptional.Optional
+	Sealed          bool         // whether Type is the sealed interface of a union, holding the value of the struct
+	NestedDefaults  bool         // whether the field's value applies default values of its own
+	Content         bool         // whether the field holds the JSON document embedded in a string, as json.RawMessage
+}
+
+// valueType returns the type of the field's value: the type it boxes, if any, or else its type
+func (s StructField) valueType() gen.TypeInfo {
+	if !s.Boxed.Unknown() {
+		return s.Boxed
+	}
+	return s.Type
 }
 
 // Validators returns the validators for this field
@@ -51,11 +69,50 @@ func (s *StructPlan) Execute(imp *gen.Imports) (string, error) {
 	return w.String(), err
 }
 
+// Decls returns the package level identifiers declared for the struct besides its type: those of its traits and, if
+// it may apply defaults, its constructor
+func (s *StructPlan) Decls() (decls []string) {
+	for _, t := range s.Traits {
+		if d, ok := t.(declsTrait); ok {
+			decls = append(decls, d.decls(s.TypeInfo.Name)...)
+		}
+	}
+	if own, held := s.Defaults(); own || len(held) > 0 {
+		decls = append(decls, "New"+s.TypeInfo.Name)
+	}
+	return
+}
+
+// Defaults returns whether any of the struct's fields have default values, and the types of its fields' values which
+// may have their own
+func (s *StructPlan) Defaults() (own bool, held []gen.TypeInfo) {
+	for _, f := range s.Fields {
+		own = own || f.Default != nil
+		if f.nested() && !f.Sealed {
+			held = append(held, f.valueType())
+		}
+	}
+	return
+}
+
+// SetDefaults records which of the struct's fields hold values applying their own defaults
+func (s *StructPlan) SetDefaults(applies func(gen.TypeInfo) bool) {
+	for i, f := range s.Fields {
+		s.Fields[i].NestedDefaults = f.nested() && !f.Sealed && applies(f.valueType())
+	}
+}
+
 // Deps returns all known required imported symbols for this plan
 func (s *StructPlan) Deps() (deps []gen.TypeInfo) {
 	deps = append(deps, gen.TypeInfo{Name: "Sprintf", GoPath: "fmt"})
 	for _, f := range s.Fields {
 		deps = append(deps, f.Type)
+		if !f.Boxed.Unknown() {
+			deps = append(deps, f.Boxed)
+		}
+		if f.Default != nil && !f.primitiveDefault() {
+			deps = append(deps, gen.TypeInfo{Name: "Unmarshal", GoPath: "encoding/json"})
+		}
 		for _, v := range f.FieldValidators {
 			deps = append(deps, v.Deps...)
 		}
@@ -86,16 +143,63 @@ func PlanObject(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen
 	// matched
 
 	s := &StructPlan{TypeInfo: tInfo, ID: schema.ID}
-	s.Comment = schema.Annotations.GetString("description")
+	s.Comment = helper.Comment(schema)
 	fields, err := deriveStructFields(ctx, helper, schema)
 	if err != nil {
 		return nil, err
 	}
 	s.Fields = fields
+	s.addBoxedTrait()
+	s.addRequiredKeysTrait()
 
 	return s, nil
 }
 
+// boxedTrait marshals a struct with boxed fields, omitting those which are unset, or with embedded documents, which are
+// encoded as strings
+type boxedTrait struct{}
+
+func (boxedTrait) Template() string {
+	return "boxed"
+}
+
+func (boxedTrait) Deps() []gen.TypeInfo {
+	return []gen.TypeInfo{{GoPath: "encoding/json", Name: "Marshal"}}
+}
+
+// addBoxedTrait adds the boxed trait if any of the struct's fields are boxed or hold embedded documents
+func (s *StructPlan) addBoxedTrait() {
+	for _, f := range s.Fields {
+		if !f.Boxed.Unknown() || f.Content {
+			s.Traits = append(s.Traits, boxedTrait{})
+			return
+		}
+	}
+}
+
+// requiredKeysTrait rejects objects lacking a required property whose field is a value, whose absence Validate can't
+// detect, and decodes the documents embedded in strings
+type requiredKeysTrait struct{}
+
+func (requiredKeysTrait) Template() string {
+	return "requiredKeys"
+}
+
+func (requiredKeysTrait) Deps() []gen.TypeInfo {
+	return []gen.TypeInfo{{GoPath: "encoding/json", Name: "Unmarshal"}, {GoPath: "encoding/json", Name: "RawMessage"}}
+}
+
+// addRequiredKeysTrait adds the required keys trait if any of the struct's required fields are values or any of its
+// fields hold embedded documents
+func (s *StructPlan) addRequiredKeysTrait() {
+	for _, f := range s.Fields {
+		if f.Required && f.primitiveValue() || f.Content {
+			s.Traits = append(s.Traits, requiredKeysTrait{})
+			return
+		}
+	}
+}
+
 func deriveStructFields(
 	ctx context.Context,
 	helper gen.Helper,
@@ -113,16 +217,25 @@ func deriveStructFields(
 	sort.Strings(properties)
 
 	for _, name := range properties {
+		if name != "" && !validTagName(name) {
+			return nil, fmt.Errorf("property %q can't be named in a json struct tag", name)
+		}
+
 		fieldSchema, err := schema.Properties[name].Resolve(ctx, schema, helper)
 		if err != nil {
 			return nil, err
 		}
+		ref := schema.Properties[name].IsRef()
 
 		if fieldSchema.Config.RawMessage {
+			def, err := defaultValue(fieldSchema, gen.JSONUnknown, true)
+			if err != nil {
+				return nil, fmt.Errorf("property %q: %w", name, err)
+			}
 			fields = append(
 				fields,
 				StructField{
-					Comment:  fieldSchema.Annotations.GetString("description"),
+					Comment:  helper.Comment(fieldSchema),
 					Name:     helper.JSONPropertyExported(name),
 					JSONName: name,
 					Type:     gen.TypeInfo{GoPath: "encoding/json", Name: "RawMessage"},
@@ -134,13 +247,20 @@ func deriveStructFields(
 						return fmt.Sprintf("`"+`json:"%s%s"`+"`", name, omitEmpty)
 					}(),
 					Required:        required[name],
-					FieldValidators: validator.Validators(fieldSchema),
+					Ref:             ref,
+					Default:         def,
+					FieldValidators: validator.Validators(ctx, fieldSchema),
 				},
 			)
 			continue
 		}
 
 		fType, _ := helper.TypeInfo(fieldSchema)
+		var (
+			defaultType gen.JSONType
+			nullable    bool
+			union       bool // whether the field is planned as a union, holding one of several types of value
+		)
 		if fType.Unknown() && len(fieldSchema.OneOf) == 2 {
 			oneOfA, err := fieldSchema.OneOf[0].Resolve(ctx, fieldSchema, helper)
 			if err != nil {
@@ -168,7 +288,30 @@ func deriveStructFields(
 					return nil, err
 				}
 				fType.Pointer = true
+				nullable = true
+				if defaultType, err = helper.DetectSimpleType(ctx, valueSchema); err != nil {
+					return nil, err
+				}
+			}
+		}
+		switch {
+		case fieldSchema.Type != nil && fieldSchema.Type.Union():
+			// planned as a union of its types, like a oneOf
+			union = true
+			nullable = fieldSchema.Type.Nullable()
+		case fieldSchema.Type != nil && fieldSchema.Type.Nullable():
+			fType.Pointer = true
+			nullable = true
+		case fType.Unknown() && !nullable && len(fieldSchema.OneOf) > 1:
+			_, err := objectBranches(ctx, helper, fieldSchema)
+			if err != nil && !errors.Is(err, gen.ErrContinue) {
+				return nil, err
 			}
+			union = err == nil
+		}
+		if union {
+			fType = helper.TypeInfoHinted(fieldSchema, gen.JSONObject)
+			fType.Pointer = true
 		}
 		fJType, err := helper.DetectSimpleType(ctx, fieldSchema)
 		if err != nil && !helper.ErrSimpleTypeUnknown(err) {
@@ -184,53 +327,266 @@ func deriveStructFields(
 		if fJType == gen.JSONUnknown && fType.Unknown() {
 			fType = gen.TypeInfo{Name: "interface{}"}
 		}
-		if !fType.BuiltIn() {
+		validators := validator.Validators(ctx, fieldSchema)
+		if union {
+			validators = []validator.Validator{validator.SubschemaValidator}
+		}
+		content := false
+		switch {
+		case fJType != gen.JSONString, nullable, union:
+		case strings.EqualFold(fieldSchema.ContentEncoding, "base64"):
+			// encoding/json decodes base64 strings into byte slices, rejecting invalid encodings
+			fType = gen.TypeInfo{Name: "[]byte"}
+			validators = validator.Base64(validators)
+		case validator.IsJSONMediaType(fieldSchema.ContentMediaType):
+			// the document is held as is, decoded from and encoded as a string by the struct
+			fType, content = rawMessage, true
+			validators = validator.Content(validators)
+		}
+		if !fType.BuiltIn() && !content {
 			if err := helper.Dep(ctx, fieldSchema); err != nil {
 				return nil, err
 			}
 		}
 
+		policy := helper.PointerPolicy(schema, fieldSchema)
+		if !policy.Valid() {
+			return nil, fmt.Errorf("property %q: unknown pointer policy %q", name, policy)
+		}
+		if policy.Generic() && !gen.TargetGoVersion(ctx).Generics() {
+			return nil, fmt.Errorf("property %q: pointer policy %q requires targeting Go 1.18 or later", name, policy)
+		}
+		if policy.Generic() && gen.IsSelfContained(ctx) {
+			return nil, fmt.Errorf("property %q: pointer policy %q uses jsoptional, so can't be self-contained", name, policy)
+		}
+		var boxed gen.TypeInfo
+		if fType.BuiltIn() && !nullable {
+			switch fType.Name {
+			case "string", "int64", "bool", "float64":
+				fType, boxed = primitiveField(fType, policy, required[name])
+			}
+		}
+
 		var tag string
 		switch {
 		case name == "": // embedded fields don't get tags
-		case (fJType == gen.JSONArray && !fieldSchema.Config.OmitEmptyArray) || fieldSchema.Config.NoOmitEmpty:
+		case (fJType == gen.JSONArray && !fieldSchema.Config.OmitEmptyArray) || fieldSchema.Config.NoOmitEmpty,
+			(StructField{Type: fType}).primitiveValue(), // a required value's zero value must still be encoded
+			fType.Name == "[]byte" && required[name]:    // as must a required empty byte slice
 			tag = fmt.Sprintf("`"+`json:"%s"`+"`", name)
 		default:
 			tag = fmt.Sprintf("`"+`json:"%s,omitempty"`+"`", name)
 		}
-
-		if fType.BuiltIn() {
-			switch fType.Name {
-			case "string", "int64", "bool", "float64":
-				fType.Pointer = true
-			}
-		}
 		// not a reference type
 		if !fType.BuiltIn() && fJType == gen.JSONObject && !fieldSchema.AdditionalProperties.Present() {
 			fType.Pointer = true
 		}
+		if policy == gen.PointersNullable && fType.Pointer {
+			// box the value rather than conflating absence and null as nil
+			fType.Pointer = false
+			fType, boxed = nullableBox, fType
+			if !nullable {
+				validators = append(validators, validator.NotNull(fieldSchema))
+			}
+		}
 
 		fieldName, ok := schema.Config.FieldAliases[name]
 		if !ok {
 			fieldName = helper.JSONPropertyExported(name)
 		}
 
+		if defaultType == gen.JSONUnknown && !union {
+			defaultType = fJType
+		}
+		def, err := defaultValue(fieldSchema, defaultType, nullable || fType.Name == "interface{}")
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		case def != nil && (fieldName == "" || !nillable(ctx, helper, fieldSchema, fType)):
+			// there's no telling whether the field is unset
+			return nil, fmt.Errorf("property %q: default can't be applied to a field which is never unset", name)
+		}
+		if def != nil {
+			// a default which can't decode would only fail when ApplyDefaults is called
+			if err := checkDefault(ctx, helper, fieldSchema, def); err != nil {
+				d := fieldSchema.Diagnostic("property %q: %v", name, err)
+				d.Pointer += "/default"
+				return nil, &gen.PlanError{Diagnostic: d}
+			}
+			if content {
+				// the field holds the document the default string contains
+				var doc string
+				if err := json.Unmarshal(def, &doc); err != nil {
+					return nil, fmt.Errorf("property %q: %w", name, err)
+				}
+				if !json.Valid([]byte(doc)) {
+					return nil, fmt.Errorf("property %q: default isn't a valid %s document", name, fieldSchema.ContentMediaType)
+				}
+				def = json.RawMessage(doc)
+			}
+		}
+
 		fields = append(
 			fields,
 			StructField{
-				Comment:         fieldSchema.Annotations.GetString("description"),
+				Comment:         helper.Comment(fieldSchema),
 				Name:            fieldName,
 				JSONName:        name,
 				Type:            fType,
 				Tag:             tag,
 				Required:        required[name],
-				FieldValidators: validator.Validators(fieldSchema),
+				Ref:             ref,
+				Default:         def,
+				FieldValidators: validators,
+				Boxed:           boxed,
+				Content:         content,
 			},
 		)
 	}
+	uniqueFieldNames(fields, schema.Config.FieldAliases)
 	return
 }
 
+// the generic boxes for fields under the generic and nullable pointer policies
+var (
+	optionalBox = gen.TypeInfo{GoPath: "github.com/ns1/jsonschema2go/pkg/jsoptional", Name: "Optional", ValPath: "Value"}
+	nullableBox = gen.TypeInfo{GoPath: "github.com/ns1/jsonschema2go/pkg/jsoptional", Name: "Nullable", ValPath: "Value"}
+)
+
+// primitiveField returns the type of a string, integer, number or boolean field under the pointer policy, and the type
+// boxed by it, if any
+func primitiveField(t gen.TypeInfo, policy gen.PointerPolicy, required bool) (_, boxed gen.TypeInfo) {
+	switch {
+	case policy == gen.PointersAlways, policy == gen.PointersNullable:
+		t.Pointer = true
+	case required:
+	case policy == gen.PointersOptional:
+		t.Pointer = true
+	default:
+		return optionalBox, t
+	}
+	return t, gen.TypeInfo{}
+}
+
+// methodNames are the methods which may be generated for a struct, and so can't be used as field names
+var methodNames = map[string]bool{
+	"ApplyDefaults": true,
+	"MarshalJSON":   true,
+	"UnmarshalJSON": true,
+	"Validate":      true,
+}
+
+// uniqueFieldNames numbers fields whose names collide after normalization, such as foo_bar and fooBar, or with a
+// generated method. Names chosen with fieldAliases are kept as is.
+func uniqueFieldNames(fields []StructField, aliases map[string]string) {
+	taken := make(map[string]bool, len(methodNames)+len(fields))
+	for name := range methodNames {
+		taken[name] = true
+	}
+	for _, f := range fields {
+		if _, ok := aliases[f.JSONName]; ok {
+			taken[f.Name] = true
+		}
+	}
+	for i, f := range fields {
+		if _, ok := aliases[f.JSONName]; ok || f.Name == "" {
+			continue
+		}
+		name := f.Name
+		for n := 2; taken[name]; n++ {
+			name = f.Name + strconv.Itoa(n)
+		}
+		taken[name] = true
+		fields[i].Name = name
+	}
+}
+
+// validTagName reports whether encoding/json accepts the name in a struct tag; names with quotes, backslashes or
+// control characters are ignored, so the field would not be mapped to the property.
+func validTagName(name string) bool {
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+	return true
+}
+
+// nillable returns whether a field of the provided type can be tested for whether it has been set
+func nillable(ctx context.Context, helper gen.Helper, schema *gen.Schema, fType gen.TypeInfo) bool {
+	if fType.Pointer || fType.ValPath != "" || fType == rawMessage {
+		return true
+	}
+	if fType.BuiltIn() {
+		switch fType.Name {
+		case "interface{}", "map[string]interface{}", "[]byte":
+			return true
+		}
+		return false
+	}
+	gTyp, err := helper.DetectGoBaseType(ctx, schema)
+	if err != nil {
+		return false
+	}
+	switch gTyp {
+	case gen.GoSlice, gen.GoMap, gen.GoEmpty:
+		return true
+	}
+	return false
+}
+
+// defaultValue returns the schema's default value, or nil if there is none. It is an error for the default not to be a
+// value of the provided JSON type, although any value is accepted when the type is unknown. A null default is only
+// accepted for nullable values, and leaves them unset.
+func defaultValue(schema *gen.Schema, jType gen.JSONType, nullable bool) (json.RawMessage, error) {
+	raw, ok := schema.Annotations["default"]
+	if !ok {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("unable to decode default: %w", err)
+	}
+
+	valid := jType == gen.JSONUnknown
+	switch v := v.(type) {
+	case nil:
+		if !nullable {
+			return nil, errors.New("default is null but the value isn't nullable")
+		}
+		return nil, nil
+	case bool:
+		valid = valid || jType == gen.JSONBoolean
+	case json.Number:
+		switch jType {
+		case gen.JSONInteger:
+			_, err := strconv.ParseInt(v.String(), 10, 64)
+			valid = err == nil
+		case gen.JSONNumber:
+			valid = true
+		}
+	case string:
+		valid = valid || jType == gen.JSONString
+	case []interface{}:
+		valid = valid || jType == gen.JSONArray
+	case map[string]interface{}:
+		valid = valid || jType == gen.JSONObject
+	}
+	if !valid {
+		return nil, fmt.Errorf("default %s doesn't match the type of the value", raw)
+	}
+
+	var w bytes.Buffer
+	if err := json.Compact(&w, raw); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
 type structPlanContext struct {
 	*StructPlan
 	*gen.Imports
@@ -240,6 +596,25 @@ func (s *structPlanContext) Comment() string {
 	return gen.NormalizeComment(s.StructPlan.Comment)
 }
 
+// AbsoluteLocation returns the quoted absolute URI of the JSON pointer relative to this struct's schema, or an empty
+// string if unknown
+func (s *structPlanContext) AbsoluteLocation(pointer string) string {
+	if s.ID == nil {
+		return ""
+	}
+	return strconv.Quote(validator.AbsoluteLocation(s.ID, pointer))
+}
+
+// ApplyDefaults returns whether this struct has any fields with default values or nested values which apply their own
+func (s *structPlanContext) ApplyDefaults() bool {
+	for _, f := range s.StructPlan.Fields {
+		if f.Default != nil || f.NestedDefaults {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *structPlanContext) ValidateInitialize() bool {
 	for _, f := range s.Fields() {
 		for _, v := range f.FieldValidators {
@@ -261,15 +636,57 @@ func (f *enrichedStructField) Comment() string {
 	return gen.NormalizeComment(f.StructField.Comment)
 }
 
+// Union returns the trait of a union, whose value must be of one of the types it lists, or nil if this isn't one
+func (s *structPlanContext) Union() *marshalOneOfTrait {
+	for _, t := range s.Traits {
+		if u, ok := t.(marshalOneOfTrait); ok {
+			return &u
+		}
+	}
+	return nil
+}
+
+// Sealed returns the trait of a union holding one of several objects, which must hold one, or nil if this isn't one
+func (s *structPlanContext) Sealed() *sealedOneOfTrait {
+	for _, t := range s.Traits {
+		if u, ok := t.(*sealedOneOfTrait); ok {
+			return u
+		}
+	}
+	return nil
+}
+
 func (s *structPlanContext) Required() []enrichedStructField {
 	var fields []StructField
-	for _, s := range s.StructPlan.Fields {
-		if s.Required {
-			fields = append(fields, s)
+	for _, f := range append(s.StructPlan.Fields, s.SubRequired...) {
+		// the absence of a primitive value is detected when decoding instead
+		if f.Required && !f.primitiveValue() {
+			fields = append(fields, f)
+		}
+	}
+
+	return s.enrich(fields)
+}
+
+// RequiredKeys returns the required fields whose absence can't be detected once decoded, as they are values
+func (s *structPlanContext) RequiredKeys() []enrichedStructField {
+	var fields []StructField
+	for _, f := range s.StructPlan.Fields {
+		if f.Required && f.primitiveValue() {
+			fields = append(fields, f)
 		}
 	}
-	fields = append(fields, s.SubRequired...)
+	return s.enrich(fields)
+}
 
+// ContentFields returns the fields holding the documents embedded in strings, which are decoded by UnmarshalJSON
+func (s *structPlanContext) ContentFields() []enrichedStructField {
+	var fields []StructField
+	for _, f := range s.StructPlan.Fields {
+		if f.Content {
+			fields = append(fields, f)
+		}
+	}
 	return s.enrich(fields)
 }
 
@@ -301,6 +718,12 @@ func (f *enrichedStructField) DerefExpr() string {
 }
 
 func (f *enrichedStructField) TestSetExpr(pos bool) (string, error) {
+	if !f.Boxed.Unknown() {
+		if !pos {
+			return fmt.Sprintf("!m.%s.Set", f.Name), nil
+		}
+		return fmt.Sprintf("m.%s.Set", f.Name), nil
+	}
 	op := "!="
 	if !pos {
 		op = "=="
@@ -308,6 +731,172 @@ func (f *enrichedStructField) TestSetExpr(pos bool) (string, error) {
 	return fmt.Sprintf("m.%s %s nil", f.Name, op), nil
 }
 
+// Guard returns the condition, followed by &&, under which the validator applies to this field: that the value is
+// set, unless the field is required, and that it isn't null if the field is nullable
+func (f *enrichedStructField) Guard(v validator.Validator) (string, error) {
+	switch {
+	case v.Null:
+		return "", nil
+	case f.Type == nullableBox:
+		return fmt.Sprintf("m.%s.Set && !m.%s.Null && ", f.Name, f.Name), nil
+	case f.Required:
+		return "", nil
+	}
+	expr, err := f.TestSetExpr(true)
+	return expr + " && ", err
+}
+
+// Operand returns the expression validated by the validator: the field's value, or whether it is null
+func (f *enrichedStructField) Operand(v validator.Validator) string {
+	if v.Null {
+		return fmt.Sprintf("m.%s.Null", f.Name)
+	}
+	return f.DerefExpr()
+}
+
+// SubschemaGuard returns the condition under which the field's value is validated against its own schema, if any
+func (f *enrichedStructField) SubschemaGuard() (string, error) {
+	switch {
+	case f.Type == nullableBox:
+		return fmt.Sprintf("m.%s.Set && !m.%s.Null", f.Name, f.Name), nil
+	case !f.Boxed.Unknown(), !f.Required && f.Type.Pointer:
+		return f.TestSetExpr(true)
+	}
+	return "", nil
+}
+
+// ValueRef returns the reference to the field's value relative to the struct, following any box
+func (f *enrichedStructField) ValueRef() string {
+	if f.Boxed.Unknown() {
+		return f.FieldRef()
+	}
+	return f.Name + "." + f.Type.ValPath
+}
+
+// KeywordPrefix returns a JSON pointer to this field's schema relative to the struct's schema, following any $ref
+func (f *enrichedStructField) KeywordPrefix() string {
+	prefix := "/properties/" + validator.PointerToken(f.JSONName)
+	if f.Ref {
+		prefix += "/$ref"
+	}
+	return prefix
+}
+
+var rawMessage = gen.TypeInfo{GoPath: "encoding/json", Name: "RawMessage"}
+
+// nested returns whether this field's type is generated elsewhere and so may have defaults of its own
+func (f StructField) nested() bool {
+	t := f.valueType()
+	return !t.BuiltIn() && t != rawMessage
+}
+
+// primitiveValue returns whether this field is a string, integer, number or boolean which is neither a pointer nor
+// boxed, and so is always set
+func (f StructField) primitiveValue() bool {
+	if f.Type.Pointer || !f.Type.BuiltIn() {
+		return false
+	}
+	switch f.Type.Name {
+	case "string", "int64", "bool", "float64":
+		return true
+	}
+	return false
+}
+
+// primitiveDefault returns whether this field's default value can be assigned as a Go literal
+func (f StructField) primitiveDefault() bool {
+	t := f.valueType()
+	if (!t.Pointer && f.Boxed.Unknown()) || !t.BuiltIn() {
+		return false
+	}
+	switch t.Name {
+	case "string", "int64", "bool", "float64":
+		return true
+	}
+	return false
+}
+
+var applyDefaultsTmpl = validator.TemplateStr(`{{ define "unmarshal" -}}
+if err := json.Unmarshal([]byte({{ .Literal }}), &m.{{ .Name }}); err != nil {
+	return fmt.Errorf("unable to apply the default of {{ .Name }}: %w", err)
+}
+{{ end -}}
+{{ with .Default -}}
+{{ if $.Boxed -}}
+if !m.{{ $.Name }}.Set {
+{{ if $.Primitive -}}
+	m.{{ $.Name }}.Value, m.{{ $.Name }}.Set = {{ $.Literal }}, true
+{{ else -}}
+	{{ template "unmarshal" $ -}}
+{{ end -}}
+}
+{{ else -}}
+if m.{{ $.Name }} == nil {
+{{ if $.Primitive -}}
+	m.{{ $.Name }} = new({{ $.Type }})
+	*m.{{ $.Name }} = {{ $.Literal }}
+{{ else -}}
+	{{ template "unmarshal" $ -}}
+{{ end -}}
+}
+{{ end -}}
+{{ end -}}
+{{ if .Nested -}}
+{{ if .Boxed }}if m.{{ .Name }}.Set {
+{{ else if .Pointer }}if m.{{ .Name }} != nil {
+{{ end -}}
+if err := m.{{ .ValueRef }}.ApplyDefaults(); err != nil {
+	return err
+}
+{{ if or .Boxed .Pointer }}}
+{{ end -}}
+{{ end -}}`)
+
+// ApplyDefaults renders the statements which set this field to its default value if unset, and apply the defaults of
+// any nested value
+func (f *enrichedStructField) ApplyDefaults() (string, error) {
+	literal := string(f.Default)
+	switch {
+	case f.Default == nil:
+	case f.primitiveDefault():
+		if f.Default[0] == '"' {
+			var s string
+			if err := json.Unmarshal(f.Default, &s); err != nil {
+				return "", err
+			}
+			literal = strconv.Quote(s)
+		}
+	case !strings.Contains(literal, "`"):
+		literal = "`" + literal + "`"
+	default:
+		literal = strconv.Quote(literal)
+	}
+
+	var w bytes.Buffer
+	err := applyDefaultsTmpl.Execute(&w, struct {
+		Name      string
+		Type      string
+		Default   json.RawMessage
+		Primitive bool
+		Literal   string
+		Nested    bool
+		Pointer   bool
+		Boxed     bool
+		ValueRef  string
+	}{
+		Name:      f.FieldRef(),
+		Type:      f.Type.Name,
+		Default:   f.Default,
+		Primitive: f.primitiveDefault(),
+		Literal:   literal,
+		Nested:    f.NestedDefaults,
+		Pointer:   f.Type.Pointer,
+		Boxed:     !f.Boxed.Unknown(),
+		ValueRef:  f.ValueRef(),
+	})
+	return w.String(), err
+}
+
 func (f *enrichedStructField) NameSpace() string {
 	name := fmt.Sprintf("%s%s", f.StructPlan.Type().Name, f.Name)
 	if len(name) > 0 {
@@ -319,16 +908,31 @@ func (f *enrichedStructField) NameSpace() string {
 }
 
 func (f *enrichedStructField) FieldDecl() string {
+	return f.Name + " " + f.typeExpr() + " " + f.Tag
+}
+
+// typeExpr returns the field's type, instantiating any box with the type of its value
+func (f *enrichedStructField) typeExpr() string {
 	typ := f.Imports.QualName(f.Type)
+	if !f.Boxed.Unknown() {
+		typ += "[" + f.Imports.QualName(f.Boxed) + "]"
+	}
 	if f.Type.Pointer {
 		typ = "*" + typ
 	}
-	return f.Name + " " + typ + " " + f.Tag
+	return typ
 }
 
+// InnerFieldDecl declares the field in the struct encoded by MarshalJSON, in which boxes are pointers so that they
+// may be omitted
 func (f *enrichedStructField) InnerFieldDecl() string {
-	typName := f.Imports.QualName(f.Type)
-	return fmt.Sprintf("%s %s %s", f.Name, typName, f.Tag)
+	if f.Content {
+		return f.Name + " *string " + f.Tag
+	}
+	if f.Boxed.Unknown() {
+		return f.FieldDecl()
+	}
+	return f.Name + " *" + f.typeExpr() + " " + f.Tag
 }
 
 func (f *enrichedStructField) Embedded() bool {
@@ -343,6 +947,9 @@ func (f *enrichedStructField) FieldRef() string {
 }
 
 func (f *enrichedStructField) InnerFieldLiteral() string {
+	if !f.Boxed.Unknown() || f.Content {
+		return ""
+	}
 	fieldRef := f.Name
 	if fieldRef == "" { // embedded
 		fieldRef = f.Type.Name
@@ -350,23 +957,29 @@ func (f *enrichedStructField) InnerFieldLiteral() string {
 	return fmt.Sprintf("%s: m.%s,", fieldRef, fieldRef)
 }
 
-var fieldAssignmentTmpl = validator.TemplateStr(`if m.{{ .Name }}.Set {
-	inner.{{ .Name }} = &m.{{ .Name }}{{ .ValPath }}
-}`)
+var fieldAssignmentTmpl = validator.TemplateStr(`{{ if .Content -}}
+if m.{{ .Name }} != nil {
+	s := string(m.{{ .Name }})
+	inner.{{ .Name }} = &s
+}
+{{- else -}}
+if m.{{ .Name }}.Set {
+	inner.{{ .Name }} = &m.{{ .Name }}
+}
+{{- end }}`)
 
 func (f *enrichedStructField) InnerFieldAssignment() (string, error) {
-	valPath := ""
-	if f.Type.ValPath != "" {
-		valPath = "." + f.Type.ValPath
+	if f.Boxed.Unknown() && !f.Content {
+		return "", nil
 	}
 
 	var w bytes.Buffer
 	err := fieldAssignmentTmpl.Execute(&w, struct {
 		Name    string
-		ValPath string
+		Content bool
 	}{
 		Name:    f.Name,
-		ValPath: valPath,
+		Content: f.Content,
 	})
 	return w.String(), err
 }
@@ -1,8 +1,18 @@
 package composite_test
 
 import (
-	"github.com/ns1/jsonschema2go/pkg/testharness"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/ns1/jsonschema2go"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+	"github.com/ns1/jsonschema2go/pkg/testharness"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPlan(t *testing.T) {
@@ -17,3 +27,155 @@ func TestPlan(t *testing.T) {
 func TestValidation(t *testing.T) {
 	testharness.RunValidationTest(t, "testdata/validation/")
 }
+
+func TestPlan_genericPointersNeedGo118(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "composite")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "foo.json")
+	r.NoError(ioutil.WriteFile(fname, []byte(`{
+  "id": "https://example.com/foo.json",
+  "properties": {
+    "bar": {"type": "string", "x-jsonschema2go": {"pointers": "nullable"}}
+  }
+}`), 0644))
+
+	for _, tt := range []struct {
+		name    string
+		options []jsonschema2go.Option
+		wantErr string
+	}{
+		{name: "schema", wantErr: `property "bar": pointer policy "nullable" requires targeting Go 1.18 or later`},
+		{
+			name:    "option",
+			options: []jsonschema2go.Option{jsonschema2go.Pointers(gen.PointersGeneric), jsonschema2go.GoVersion("1.17")},
+			wantErr: `pointer policy "generic" requires targeting Go 1.18 or later; set GoVersion`,
+		},
+		{name: "go1.18", options: []jsonschema2go.Option{jsonschema2go.GoVersion("1.18")}},
+		{
+			name:    "self-contained schema",
+			options: []jsonschema2go.Option{jsonschema2go.GoVersion("1.18"), jsonschema2go.SelfContained(true)},
+			wantErr: `property "bar": pointer policy "nullable" uses jsoptional, so can't be self-contained`,
+		},
+		{
+			name: "self-contained option",
+			options: []jsonschema2go.Option{
+				jsonschema2go.Pointers(gen.PointersGeneric),
+				jsonschema2go.GoVersion("1.18"),
+				jsonschema2go.SelfContained(true),
+			},
+			wantErr: `pointer policy "generic" uses jsoptional, so can't be self-contained`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			options := append(
+				tt.options,
+				jsonschema2go.TypeFromID("https://example.com", "example.com/foo"),
+				jsonschema2go.PrefixMap("example.com/foo", filepath.Join(dir, tt.name)),
+			)
+			err := jsonschema2go.Generate(context.Background(), []string{"file:" + fname}, options...)
+			if tt.wantErr == "" {
+				r.NoError(err)
+				return
+			}
+			r.Error(err)
+			r.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPlan_defaultsMustDecode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "composite")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, tt := range []struct {
+		name, property, wantErr string
+	}{
+		{
+			name:     "valid",
+			property: `{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["a"], "default": {"a": "b"}}`,
+		},
+		{
+			name:     "required",
+			property: `{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["a"], "default": {}}`,
+			wantErr:  `property "bar": default: required property "a" is missing`,
+		},
+		{
+			name:     "nested type",
+			property: `{"type": "array", "items": {"type": "integer"}, "default": [1, 2.5]}`,
+			wantErr:  `property "bar": default at /1: 2.5 isn't of type "integer"`,
+		},
+		{
+			name: "union",
+			property: `{"type": "object", "properties": {"a": {"type": ["string", "boolean"]}},
+				"default": {"a": 1}}`,
+			wantErr: `property "bar": default at /a: 1 isn't of type ["string","boolean"]`,
+		},
+		{
+			name: "oneOf",
+			property: `{"oneOf": [
+				{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["a"]},
+				{"type": "object", "properties": {"b": {"type": "string"}}, "required": ["b"]}
+			], "default": {"a": "x", "b": "y"}}`,
+			wantErr: `property "bar": default: matches more than one of the oneOf schemas`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			fname := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			r.NoError(ioutil.WriteFile(fname, []byte(`{
+  "id": "https://example.com/foo.json",
+  "properties": {
+    "bar": `+tt.property+`
+  }
+}`), 0644))
+
+			err := jsonschema2go.Generate(
+				context.Background(),
+				[]string{"file:" + fname},
+				jsonschema2go.TypeFromID("https://example.com", "example.com/foo"),
+				jsonschema2go.PrefixMap("example.com/foo", filepath.Join(dir, tt.name)),
+			)
+			if tt.wantErr == "" {
+				r.NoError(err)
+				return
+			}
+			var planErr *gen.PlanError
+			r.True(errors.As(err, &planErr), "expected a plan error but got %v", err)
+			r.Equal("/properties/bar/default", planErr.Pointer)
+			r.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPlan_unionAccessorsMustBeDistinct(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "composite")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "foo.json")
+	r.NoError(ioutil.WriteFile(fname, []byte(`{
+  "id": "https://example.com/foo.json",
+  "oneOf": [
+    {"type": "object", "properties": {"a": {"type": "string"}}, "x-jsonschema2go": {"gopath": "example.com/foo#String"}},
+    {"type": "string"}
+  ]
+}`), 0644))
+
+	err = jsonschema2go.Generate(
+		context.Background(),
+		[]string{"file:" + fname},
+		jsonschema2go.TypeFromID("https://example.com", "example.com/foo"),
+		jsonschema2go.PrefixMap("example.com/foo", dir),
+	)
+	r.Error(err)
+	r.Contains(err.Error(), "branches of types String and string would both declare AsString, FooFromString and FooKindString")
+}
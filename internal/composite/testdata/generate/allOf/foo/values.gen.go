@@ -2,7 +2,7 @@
 package foo
 
 import (
-	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/allOf/foo/bar.json
@@ -15,54 +15,24 @@ type Bar struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/allOf/foo/bar.json
 func (m *Bar) Validate() error {
 	if m.Bar == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Bar"},
-			jsonPath: []interface{}{"bar"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Bar"},
+			JSONPath:                []interface{}{"bar"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/allOf/foo/bar.json#/required",
 		}
 	}
 	if m.Foo == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Foo"},
-			jsonPath: []interface{}{"foo"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Foo"},
+			JSONPath:                []interface{}{"foo"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/allOf/foo/bar.json#/required",
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
@@ -27,19 +28,23 @@ type DhcpOption struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/dhcp-option.json
 func (m *DhcpOption) Validate() error {
 	if m.Name == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Name"},
-			jsonPath: []interface{}{"name"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Name"},
+			JSONPath:                []interface{}{"name"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/dhcp-option.json#/required",
 		}
 	}
 	if m.Value == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Value"},
-			jsonPath: []interface{}{"value"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Value"},
+			JSONPath:                []interface{}{"value"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/dhcp-option.json#/required",
 		}
 	}
 	return nil
@@ -57,28 +62,20 @@ type DhcpScopeGroupSettingsCommon struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-common.json
 func (m *DhcpScopeGroupSettingsCommon) Validate() error {
 	if err := m.Options.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Options"}, err.Path()...),
-				jsonPath: append([]interface{}{"options"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Options", "options", "/properties/options")
 	}
 	return nil
 }
 
-// DhcpScopeGroupSettingsSynthesizeDnsRecords is generated from https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-synthesize-dns-records.json
-type DhcpScopeGroupSettingsSynthesizeDnsRecords struct {
+// DhcpScopeGroupSettingsSynthesizeDNSRecords is generated from https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-synthesize-dns-records.json
+type DhcpScopeGroupSettingsSynthesizeDNSRecords struct {
 	Enabled          *bool   `json:"enabled,omitempty"`
 	GeneratedPrefix  *string `json:"generated_prefix,omitempty"`
 	QualifyingSuffix *string `json:"qualifying_suffix,omitempty"`
 }
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-synthesize-dns-records.json
-func (m *DhcpScopeGroupSettingsSynthesizeDnsRecords) Validate() error {
+func (m *DhcpScopeGroupSettingsSynthesizeDNSRecords) Validate() error {
 	return nil
 }
 
@@ -102,22 +99,14 @@ func (m *DhcpScopeGroupSettingsV4) Validate() error {
 // DhcpScopeGroupSettingsV4AllOf1 is generated from https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-v4.json#/allOf/1
 type DhcpScopeGroupSettingsV4AllOf1 struct {
 	EchoClientID         *bool                                       `json:"echo_client_id,omitempty"`
-	SynthesizeDnsRecords *DhcpScopeGroupSettingsSynthesizeDnsRecords `json:"synthesize_dns_records,omitempty"`
+	SynthesizeDNSRecords *DhcpScopeGroupSettingsSynthesizeDNSRecords `json:"synthesize_dns_records,omitempty"`
 }
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-v4.json#/allOf/1
 func (m *DhcpScopeGroupSettingsV4AllOf1) Validate() error {
-	if m.SynthesizeDnsRecords != nil {
-		if err := m.SynthesizeDnsRecords.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"SynthesizeDnsRecords"}, err.Path()...),
-					jsonPath: append([]interface{}{"synthesize_dns_records"}, err.JSONPath()...),
-				}
-			}
-			return err
+	if m.SynthesizeDNSRecords != nil {
+		if err := m.SynthesizeDNSRecords.Validate(); err != nil {
+			return jsvalidate.Prefix(err, "SynthesizeDNSRecords", "synthesize_dns_records", "/properties/synthesize_dns_records/$ref")
 		}
 	}
 	return nil
@@ -143,22 +132,14 @@ func (m *DhcpScopeGroupSettingsV6) Validate() error {
 // DhcpScopeGroupSettingsV6AllOf1 is generated from https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-v6.json#/allOf/1
 type DhcpScopeGroupSettingsV6AllOf1 struct {
 	PreferredLifetimeSecs *int64                                      `json:"preferred_lifetime_secs,omitempty"`
-	SynthesizeDnsRecords  *DhcpScopeGroupSettingsSynthesizeDnsRecords `json:"synthesize_dns_records,omitempty"`
+	SynthesizeDNSRecords  *DhcpScopeGroupSettingsSynthesizeDNSRecords `json:"synthesize_dns_records,omitempty"`
 }
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/dhcp-scope-group-settings-v6.json#/allOf/1
 func (m *DhcpScopeGroupSettingsV6AllOf1) Validate() error {
-	if m.SynthesizeDnsRecords != nil {
-		if err := m.SynthesizeDnsRecords.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"SynthesizeDnsRecords"}, err.Path()...),
-					jsonPath: append([]interface{}{"synthesize_dns_records"}, err.JSONPath()...),
-				}
-			}
-			return err
+	if m.SynthesizeDNSRecords != nil {
+		if err := m.SynthesizeDNSRecords.Validate(); err != nil {
+			return jsvalidate.Prefix(err, "SynthesizeDNSRecords", "synthesize_dns_records", "/properties/synthesize_dns_records/$ref")
 		}
 	}
 	return nil
@@ -190,15 +171,7 @@ type ExpandedReservationAllOf1 struct {
 func (m *ExpandedReservationAllOf1) Validate() error {
 	if m.AddressDetails != nil {
 		if err := m.AddressDetails.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"AddressDetails"}, err.Path()...),
-					jsonPath: append([]interface{}{"address_details"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "AddressDetails", "address_details", "/properties/address_details/$ref")
 		}
 	}
 	return nil
@@ -230,15 +203,7 @@ type ExpandedScopeAllOf1 struct {
 func (m *ExpandedScopeAllOf1) Validate() error {
 	if m.AddressDetails != nil {
 		if err := m.AddressDetails.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"AddressDetails"}, err.Path()...),
-					jsonPath: append([]interface{}{"address_details"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "AddressDetails", "address_details", "/properties/address_details/$ref")
 		}
 	}
 	return nil
@@ -270,26 +235,10 @@ type ExpandedScopeGroupResponseAllOf1 struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/expanded-scope-group-response.json#/allOf/1
 func (m *ExpandedScopeGroupResponseAllOf1) Validate() error {
 	if err := m.Reservations.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Reservations"}, err.Path()...),
-				jsonPath: append([]interface{}{"reservations"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Reservations", "reservations", "/properties/reservations")
 	}
 	if err := m.Scopes.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Scopes"}, err.Path()...),
-				jsonPath: append([]interface{}{"scopes"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Scopes", "scopes", "/properties/scopes")
 	}
 	return nil
 }
@@ -303,11 +252,13 @@ type Reservation struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/reservation.json
 func (m *Reservation) Validate() error {
 	if m.ID == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"ID"},
-			jsonPath: []interface{}{"id"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"ID"},
+			JSONPath:                []interface{}{"id"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/reservation.json#/required",
 		}
 	}
 	if err := m.ReservationAllOf0.Validate(); err != nil {
@@ -345,31 +296,27 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/reservation-fields.json
 func (m *ReservationFields) Validate() error {
 	if m.Options == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Options"},
-			jsonPath: []interface{}{"options"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Options"},
+			JSONPath:                []interface{}{"options"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/reservation-fields.json#/required",
 		}
 	}
 	if m.Mac != nil && !reservationFieldsMacPattern.MatchString(*m.Mac) {
-		return &validationError{
-			errType:  "pattern",
-			path:     []interface{}{"Mac"},
-			jsonPath: []interface{}{"mac"},
-			message:  fmt.Sprintf(`must match '^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$' but got %q`, *m.Mac),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "pattern",
+			Path:                    []interface{}{"Mac"},
+			JSONPath:                []interface{}{"mac"},
+			Message:                 fmt.Sprintf(`must match '^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$' but got %q`, *m.Mac),
+			KeywordLocation:         "/properties/mac/pattern",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/reservation-fields.json#/properties/mac/pattern",
 		}
 	}
 	if err := m.Options.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Options"}, err.Path()...),
-				jsonPath: append([]interface{}{"options"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Options", "options", "/properties/options")
 	}
 	return nil
 }
@@ -412,31 +359,27 @@ type ScopeFields struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/scope-fields.json
 func (m *ScopeFields) Validate() error {
 	if m.AddressID == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"AddressID"},
-			jsonPath: []interface{}{"address_id"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"AddressID"},
+			JSONPath:                []interface{}{"address_id"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/scope-fields.json#/required",
 		}
 	}
 	if m.Options == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Options"},
-			jsonPath: []interface{}{"options"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Options"},
+			JSONPath:                []interface{}{"options"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/scope-fields.json#/required",
 		}
 	}
 	if err := m.Options.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Options"}, err.Path()...),
-				jsonPath: append([]interface{}{"options"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Options", "options", "/properties/options")
 	}
 	return nil
 }
@@ -450,35 +393,43 @@ type ScopeGroupResponse struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/scope-group-response.json
 func (m *ScopeGroupResponse) Validate() error {
 	if m.Dhcpv4 == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Dhcpv4"},
-			jsonPath: []interface{}{"dhcpv4"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Dhcpv4"},
+			JSONPath:                []interface{}{"dhcpv4"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/scope-group-response.json#/required",
 		}
 	}
 	if m.Dhcpv6 == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Dhcpv6"},
-			jsonPath: []interface{}{"dhcpv6"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Dhcpv6"},
+			JSONPath:                []interface{}{"dhcpv6"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/scope-group-response.json#/required",
 		}
 	}
 	if m.ID == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"ID"},
-			jsonPath: []interface{}{"id"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"ID"},
+			JSONPath:                []interface{}{"id"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/scope-group-response.json#/required",
 		}
 	}
 	if m.Name == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Name"},
-			jsonPath: []interface{}{"name"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Name"},
+			JSONPath:                []interface{}{"name"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/complex/foo/scope-group-response.json#/required",
 		}
 	}
 	if err := m.ScopeGroupUpdateable.Validate(); err != nil {
@@ -507,35 +458,19 @@ type ScopeGroupUpdateable struct {
 	Dhcpv4        *DhcpScopeGroupSettingsV4 `json:"dhcpv4,omitempty"`
 	Dhcpv6        *DhcpScopeGroupSettingsV6 `json:"dhcpv6,omitempty"`
 	Name          *string                   `json:"name,omitempty"`
-	ReverseDns    *bool                     `json:"reverse_dns,omitempty"`
+	ReverseDNS    *bool                     `json:"reverse_dns,omitempty"`
 }
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/complex/foo/scope-group-updateable.json
 func (m *ScopeGroupUpdateable) Validate() error {
 	if m.Dhcpv4 != nil {
 		if err := m.Dhcpv4.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"Dhcpv4"}, err.Path()...),
-					jsonPath: append([]interface{}{"dhcpv4"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "Dhcpv4", "dhcpv4", "/properties/dhcpv4/$ref")
 		}
 	}
 	if m.Dhcpv6 != nil {
 		if err := m.Dhcpv6.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"Dhcpv6"}, err.Path()...),
-					jsonPath: append([]interface{}{"dhcpv6"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "Dhcpv6", "dhcpv6", "/properties/dhcpv6/$ref")
 		}
 	}
 	return nil
@@ -548,15 +483,7 @@ type DhcpScopeGroupSettingsCommonOptions []*DhcpOption
 func (m DhcpScopeGroupSettingsCommonOptions) Validate() error {
 	for i := range m {
 		if err := m[i].Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{i}, err.Path()...),
-					jsonPath: append([]interface{}{i}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, i, i, "/items/$ref")
 		}
 	}
 	return nil
@@ -569,15 +496,7 @@ type ExpandedScopeGroupResponseAllOf1Reservations []*ExpandedReservation
 func (m ExpandedScopeGroupResponseAllOf1Reservations) Validate() error {
 	for i := range m {
 		if err := m[i].Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{i}, err.Path()...),
-					jsonPath: append([]interface{}{i}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, i, i, "/items/$ref")
 		}
 	}
 	return nil
@@ -590,15 +509,7 @@ type ExpandedScopeGroupResponseAllOf1Scopes []*ExpandedScope
 func (m ExpandedScopeGroupResponseAllOf1Scopes) Validate() error {
 	for i := range m {
 		if err := m[i].Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{i}, err.Path()...),
-					jsonPath: append([]interface{}{i}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, i, i, "/items/$ref")
 		}
 	}
 	return nil
@@ -612,15 +523,7 @@ type ReservationFieldsOptions []*DhcpOption
 func (m ReservationFieldsOptions) Validate() error {
 	for i := range m {
 		if err := m[i].Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{i}, err.Path()...),
-					jsonPath: append([]interface{}{i}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, i, i, "/items/$ref")
 		}
 	}
 	return nil
@@ -634,50 +537,8 @@ type ScopeFieldsOptions []*DhcpOption
 func (m ScopeFieldsOptions) Validate() error {
 	for i := range m {
 		if err := m[i].Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{i}, err.Path()...),
-					jsonPath: append([]interface{}{i}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, i, i, "/items/$ref")
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -2,7 +2,7 @@
 package foo
 
 import (
-	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/composite_validation/foo/bar.json
@@ -31,11 +31,13 @@ type Baz struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/composite_validation/foo/baz.json
 func (m *Baz) Validate() error {
 	if m.Name == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Name"},
-			jsonPath: []interface{}{"name"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Name"},
+			JSONPath:                []interface{}{"name"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/composite_validation/foo/baz.json#/required",
 		}
 	}
 	return nil
@@ -49,46 +51,14 @@ type Blob struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/composite_validation/foo/blob.json
 func (m *Blob) Validate() error {
 	if m.Count == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Count"},
-			jsonPath: []interface{}{"count"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Count"},
+			JSONPath:                []interface{}{"count"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/composite_validation/foo/blob.json#/required",
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -2,7 +2,7 @@
 package foo
 
 import (
-	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/double_nested/foo/bar.json
@@ -15,15 +15,7 @@ type Bar struct {
 func (m *Bar) Validate() error {
 	if m.Foo != nil {
 		if err := m.Foo.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"Foo"}, err.Path()...),
-					jsonPath: append([]interface{}{"foo"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "Foo", "foo", "/properties/foo")
 		}
 	}
 	return nil
@@ -48,50 +40,8 @@ type Foo struct {
 func (m *Foo) Validate() error {
 	if m.Baz != nil {
 		if err := m.Baz.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"Baz"}, err.Path()...),
-					jsonPath: append([]interface{}{"baz"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "Baz", "baz", "/properties/baz/$ref")
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
@@ -20,62 +21,34 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/example/foo/bar.json
 func (m *Bar) Validate() error {
 	if m.Baz == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"Baz"},
-			jsonPath: []interface{}{"baz"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"Baz"},
+			JSONPath:                []interface{}{"baz"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/example/foo/bar.json#/required",
 		}
 	}
 	if !barBazPattern.MatchString(*m.Baz) {
-		return &validationError{
-			errType:  "pattern",
-			path:     []interface{}{"Baz"},
-			jsonPath: []interface{}{"baz"},
-			message:  fmt.Sprintf(`must match '^[0-9a-fA-F]{10}$' but got %q`, *m.Baz),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "pattern",
+			Path:                    []interface{}{"Baz"},
+			JSONPath:                []interface{}{"baz"},
+			Message:                 fmt.Sprintf(`must match '^[0-9a-fA-F]{10}$' but got %q`, *m.Baz),
+			KeywordLocation:         "/properties/baz/pattern",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/example/foo/bar.json#/properties/baz/pattern",
 		}
 	}
 	if m.Count != nil && *m.Count < 3 {
-		return &validationError{
-			errType:  "minimum",
-			path:     []interface{}{"Count"},
-			jsonPath: []interface{}{"count"},
-			message:  fmt.Sprintf("must be greater than or equal to 3 but was %v", *m.Count),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimum",
+			Path:                    []interface{}{"Count"},
+			JSONPath:                []interface{}{"count"},
+			Message:                 fmt.Sprintf("must be greater than or equal to 3 but was %v", *m.Count),
+			KeywordLocation:         "/properties/count/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/example/foo/bar.json#/properties/count/minimum",
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
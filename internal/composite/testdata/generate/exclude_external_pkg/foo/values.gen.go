@@ -2,8 +2,8 @@
 package foo
 
 import (
-	"fmt"
 	"github.com/ns1/jsonschema2go/internal/composite/testdata/generate/exclude_external_pkg/other"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/exclude_external_pkg/foo/example.json
@@ -17,50 +17,8 @@ type Bar struct {
 func (m *Bar) Validate() error {
 	if m.Inner != nil {
 		if err := m.Inner.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"Inner"}, err.Path()...),
-					jsonPath: append([]interface{}{"inner"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "Inner", "inner", "/properties/inner")
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
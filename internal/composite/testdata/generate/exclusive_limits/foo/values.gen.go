@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/exclusive_limits/foo/bar.json
@@ -15,70 +16,44 @@ type Bar struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/exclusive_limits/foo/bar.json
 func (m *Bar) Validate() error {
 	if m.ExclInteger30 != nil && *m.ExclInteger30 >= 10 {
-		return &validationError{
-			errType:  "maximumExclusive",
-			path:     []interface{}{"ExclInteger30"},
-			jsonPath: []interface{}{"exclInteger_3_0"},
-			message:  fmt.Sprintf("must be less than 10 but was %v", *m.ExclInteger30),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maximumExclusive",
+			Path:                    []interface{}{"ExclInteger30"},
+			JSONPath:                []interface{}{"exclInteger_3_0"},
+			Message:                 fmt.Sprintf("must be less than 10 but was %v", *m.ExclInteger30),
+			KeywordLocation:         "/properties/exclInteger_3_0/maximum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/exclusive_limits/foo/bar.json#/properties/exclInteger_3_0/maximum",
 		}
 	}
 	if m.ExclInteger30 != nil && *m.ExclInteger30 <= 1 {
-		return &validationError{
-			errType:  "minimumExclusive",
-			path:     []interface{}{"ExclInteger30"},
-			jsonPath: []interface{}{"exclInteger_3_0"},
-			message:  fmt.Sprintf("must be greater than 1 but was %v", *m.ExclInteger30),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimumExclusive",
+			Path:                    []interface{}{"ExclInteger30"},
+			JSONPath:                []interface{}{"exclInteger_3_0"},
+			Message:                 fmt.Sprintf("must be greater than 1 but was %v", *m.ExclInteger30),
+			KeywordLocation:         "/properties/exclInteger_3_0/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/exclusive_limits/foo/bar.json#/properties/exclInteger_3_0/minimum",
 		}
 	}
 	if m.ExclInteger31 != nil && *m.ExclInteger31 >= 10 {
-		return &validationError{
-			errType:  "maximumExclusive",
-			path:     []interface{}{"ExclInteger31"},
-			jsonPath: []interface{}{"exclInteger_3_1"},
-			message:  fmt.Sprintf("must be less than 10 but was %v", *m.ExclInteger31),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maximumExclusive",
+			Path:                    []interface{}{"ExclInteger31"},
+			JSONPath:                []interface{}{"exclInteger_3_1"},
+			Message:                 fmt.Sprintf("must be less than 10 but was %v", *m.ExclInteger31),
+			KeywordLocation:         "/properties/exclInteger_3_1/exclusiveMaximum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/exclusive_limits/foo/bar.json#/properties/exclInteger_3_1/exclusiveMaximum",
 		}
 	}
 	if m.ExclInteger31 != nil && *m.ExclInteger31 <= 1 {
-		return &validationError{
-			errType:  "minimumExclusive",
-			path:     []interface{}{"ExclInteger31"},
-			jsonPath: []interface{}{"exclInteger_3_1"},
-			message:  fmt.Sprintf("must be greater than 1 but was %v", *m.ExclInteger31),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimumExclusive",
+			Path:                    []interface{}{"ExclInteger31"},
+			JSONPath:                []interface{}{"exclInteger_3_1"},
+			Message:                 fmt.Sprintf("must be greater than 1 but was %v", *m.ExclInteger31),
+			KeywordLocation:         "/properties/exclInteger_3_1/exclusiveMinimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/exclusive_limits/foo/bar.json#/properties/exclInteger_3_1/exclusiveMinimum",
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
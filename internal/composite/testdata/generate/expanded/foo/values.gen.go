@@ -2,7 +2,7 @@
 package foo
 
 import (
-	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/expanded/foo/bar.json
@@ -31,15 +31,7 @@ type BarAllOf1 struct {
 func (m *BarAllOf1) Validate() error {
 	if m.Parent != nil {
 		if err := m.Parent.Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{"Parent"}, err.Path()...),
-					jsonPath: append([]interface{}{"parent"}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, "Parent", "parent", "/properties/parent/$ref")
 		}
 	}
 	return nil
@@ -57,37 +49,3 @@ func (m *Foo) Validate() error {
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"math"
 	"regexp"
 )
@@ -31,150 +32,176 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/field_validators/foo/bar.json
 func (m *Bar) Validate() error {
 	if m.String == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"String"},
-			jsonPath: []interface{}{"string"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"String"},
+			JSONPath:                []interface{}{"string"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/required",
 		}
 	}
 	if err := m.Array.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Array"}, err.Path()...),
-				jsonPath: append([]interface{}{"array"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Array", "array", "/properties/array")
 	}
 	if m.ExclInteger != nil && *m.ExclInteger >= 10 {
-		return &validationError{
-			errType:  "maximumExclusive",
-			path:     []interface{}{"ExclInteger"},
-			jsonPath: []interface{}{"exclInteger"},
-			message:  fmt.Sprintf("must be less than 10 but was %v", *m.ExclInteger),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maximumExclusive",
+			Path:                    []interface{}{"ExclInteger"},
+			JSONPath:                []interface{}{"exclInteger"},
+			Message:                 fmt.Sprintf("must be less than 10 but was %v", *m.ExclInteger),
+			KeywordLocation:         "/properties/exclInteger/maximum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/exclInteger/maximum",
 		}
 	}
 	if m.ExclInteger != nil && *m.ExclInteger <= 1 {
-		return &validationError{
-			errType:  "minimumExclusive",
-			path:     []interface{}{"ExclInteger"},
-			jsonPath: []interface{}{"exclInteger"},
-			message:  fmt.Sprintf("must be greater than 1 but was %v", *m.ExclInteger),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimumExclusive",
+			Path:                    []interface{}{"ExclInteger"},
+			JSONPath:                []interface{}{"exclInteger"},
+			Message:                 fmt.Sprintf("must be greater than 1 but was %v", *m.ExclInteger),
+			KeywordLocation:         "/properties/exclInteger/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/exclInteger/minimum",
 		}
 	}
 	if m.ExclNumber != nil && *m.ExclNumber >= 10.2 {
-		return &validationError{
-			errType:  "maximumExclusive",
-			path:     []interface{}{"ExclNumber"},
-			jsonPath: []interface{}{"exclNumber"},
-			message:  fmt.Sprintf("must be less than 10.2 but was %v", *m.ExclNumber),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maximumExclusive",
+			Path:                    []interface{}{"ExclNumber"},
+			JSONPath:                []interface{}{"exclNumber"},
+			Message:                 fmt.Sprintf("must be less than 10.2 but was %v", *m.ExclNumber),
+			KeywordLocation:         "/properties/exclNumber/maximum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/exclNumber/maximum",
 		}
 	}
 	if m.ExclNumber != nil && *m.ExclNumber <= 1 {
-		return &validationError{
-			errType:  "minimumExclusive",
-			path:     []interface{}{"ExclNumber"},
-			jsonPath: []interface{}{"exclNumber"},
-			message:  fmt.Sprintf("must be greater than 1 but was %v", *m.ExclNumber),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimumExclusive",
+			Path:                    []interface{}{"ExclNumber"},
+			JSONPath:                []interface{}{"exclNumber"},
+			Message:                 fmt.Sprintf("must be greater than 1 but was %v", *m.ExclNumber),
+			KeywordLocation:         "/properties/exclNumber/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/exclNumber/minimum",
 		}
 	}
 	if m.Integer != nil && !barIntegerEnum[*m.Integer] {
-		return &validationError{
-			errType:  "enum",
-			path:     []interface{}{"Integer"},
-			jsonPath: []interface{}{"integer"},
-			message:  fmt.Sprintf(`must be one of (3, 6, 9) but got %v`, *m.Integer),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "enum",
+			Path:                    []interface{}{"Integer"},
+			JSONPath:                []interface{}{"integer"},
+			Message:                 fmt.Sprintf(`must be one of (3, 6, 9) but got %v`, *m.Integer),
+			KeywordLocation:         "/properties/integer/enum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/integer/enum",
 		}
 	}
 	if m.Integer != nil && *m.Integer > 10 {
-		return &validationError{
-			errType:  "maximum",
-			path:     []interface{}{"Integer"},
-			jsonPath: []interface{}{"integer"},
-			message:  fmt.Sprintf("must be less than or equal to 10 but was %v", *m.Integer),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maximum",
+			Path:                    []interface{}{"Integer"},
+			JSONPath:                []interface{}{"integer"},
+			Message:                 fmt.Sprintf("must be less than or equal to 10 but was %v", *m.Integer),
+			KeywordLocation:         "/properties/integer/maximum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/integer/maximum",
 		}
 	}
 	if m.Integer != nil && *m.Integer < 1 {
-		return &validationError{
-			errType:  "minimum",
-			path:     []interface{}{"Integer"},
-			jsonPath: []interface{}{"integer"},
-			message:  fmt.Sprintf("must be greater than or equal to 1 but was %v", *m.Integer),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimum",
+			Path:                    []interface{}{"Integer"},
+			JSONPath:                []interface{}{"integer"},
+			Message:                 fmt.Sprintf("must be greater than or equal to 1 but was %v", *m.Integer),
+			KeywordLocation:         "/properties/integer/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/integer/minimum",
 		}
 	}
 	if m.Integer != nil && *m.Integer%3 != 0 {
-		return &validationError{
-			errType:  "multipleOf",
-			path:     []interface{}{"Integer"},
-			jsonPath: []interface{}{"integer"},
-			message:  fmt.Sprintf("must be a multiple of 3 but was %v", *m.Integer),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "multipleOf",
+			Path:                    []interface{}{"Integer"},
+			JSONPath:                []interface{}{"integer"},
+			Message:                 fmt.Sprintf("must be a multiple of 3 but was %v", *m.Integer),
+			KeywordLocation:         "/properties/integer/multipleOf",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/integer/multipleOf",
 		}
 	}
 	if m.Number != nil && !barNumberEnum[*m.Number] {
-		return &validationError{
-			errType:  "enum",
-			path:     []interface{}{"Number"},
-			jsonPath: []interface{}{"number"},
-			message:  fmt.Sprintf(`must be one of (3.2, 6.4, 9.6) but got %v`, *m.Number),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "enum",
+			Path:                    []interface{}{"Number"},
+			JSONPath:                []interface{}{"number"},
+			Message:                 fmt.Sprintf(`must be one of (3.2, 6.4, 9.6) but got %v`, *m.Number),
+			KeywordLocation:         "/properties/number/enum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/number/enum",
 		}
 	}
 	if m.Number != nil && *m.Number > 10.2 {
-		return &validationError{
-			errType:  "maximum",
-			path:     []interface{}{"Number"},
-			jsonPath: []interface{}{"number"},
-			message:  fmt.Sprintf("must be less than or equal to 10.2 but was %v", *m.Number),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maximum",
+			Path:                    []interface{}{"Number"},
+			JSONPath:                []interface{}{"number"},
+			Message:                 fmt.Sprintf("must be less than or equal to 10.2 but was %v", *m.Number),
+			KeywordLocation:         "/properties/number/maximum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/number/maximum",
 		}
 	}
 	if m.Number != nil && *m.Number < 1 {
-		return &validationError{
-			errType:  "minimum",
-			path:     []interface{}{"Number"},
-			jsonPath: []interface{}{"number"},
-			message:  fmt.Sprintf("must be greater than or equal to 1 but was %v", *m.Number),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimum",
+			Path:                    []interface{}{"Number"},
+			JSONPath:                []interface{}{"number"},
+			Message:                 fmt.Sprintf("must be greater than or equal to 1 but was %v", *m.Number),
+			KeywordLocation:         "/properties/number/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/number/minimum",
 		}
 	}
 	if m.Number != nil && math.Mod(*m.Number, 3.2) != 0 {
-		return &validationError{
-			errType:  "multipleOf",
-			path:     []interface{}{"Number"},
-			jsonPath: []interface{}{"number"},
-			message:  fmt.Sprintf("must be a multiple of 3.2 but was %v", *m.Number),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "multipleOf",
+			Path:                    []interface{}{"Number"},
+			JSONPath:                []interface{}{"number"},
+			Message:                 fmt.Sprintf("must be a multiple of 3.2 but was %v", *m.Number),
+			KeywordLocation:         "/properties/number/multipleOf",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/number/multipleOf",
 		}
 	}
 	if !barStringEnum[*m.String] {
-		return &validationError{
-			errType:  "enum",
-			path:     []interface{}{"String"},
-			jsonPath: []interface{}{"string"},
-			message:  fmt.Sprintf(`must be one of ("123", "456") but got %v`, *m.String),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "enum",
+			Path:                    []interface{}{"String"},
+			JSONPath:                []interface{}{"string"},
+			Message:                 fmt.Sprintf(`must be one of ("123", "456") but got %v`, *m.String),
+			KeywordLocation:         "/properties/string/enum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/string/enum",
 		}
 	}
 	if len(*m.String) > 10 {
-		return &validationError{
-			errType:  "maxLength",
-			path:     []interface{}{"String"},
-			jsonPath: []interface{}{"string"},
-			message:  fmt.Sprintf("must have length less than 10 but was %d", len(*m.String)),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maxLength",
+			Path:                    []interface{}{"String"},
+			JSONPath:                []interface{}{"string"},
+			Message:                 fmt.Sprintf("must have length less than 10 but was %d", len(*m.String)),
+			KeywordLocation:         "/properties/string/maxLength",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/string/maxLength",
 		}
 	}
 	if len(*m.String) < 3 {
-		return &validationError{
-			errType:  "minLength",
-			path:     []interface{}{"String"},
-			jsonPath: []interface{}{"string"},
-			message:  fmt.Sprintf("must have length greater than 3 but was %d", len(*m.String)),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minLength",
+			Path:                    []interface{}{"String"},
+			JSONPath:                []interface{}{"string"},
+			Message:                 fmt.Sprintf("must have length greater than 3 but was %d", len(*m.String)),
+			KeywordLocation:         "/properties/string/minLength",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/string/minLength",
 		}
 	}
 	if !barStringPattern.MatchString(*m.String) {
-		return &validationError{
-			errType:  "pattern",
-			path:     []interface{}{"String"},
-			jsonPath: []interface{}{"string"},
-			message:  fmt.Sprintf(`must match '^(123|456)$' but got %q`, *m.String),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "pattern",
+			Path:                    []interface{}{"String"},
+			JSONPath:                []interface{}{"string"},
+			Message:                 fmt.Sprintf(`must match '^(123|456)$' but got %q`, *m.String),
+			KeywordLocation:         "/properties/string/pattern",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/string/pattern",
 		}
 	}
 	return nil
@@ -186,62 +213,34 @@ type BarArray []string
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/array
 func (m BarArray) Validate() error {
 	if len(m) > 10 {
-		return &validationError{
-			errType: "maxItems",
-			message: fmt.Sprintf("must have length greater than 10 but was %d", len(m)),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "maxItems",
+			Message:                 fmt.Sprintf("must have length less than 10 but was %d", len(m)),
+			KeywordLocation:         "/maxItems",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/array/maxItems",
 		}
 	}
 	if len(m) < 1 {
-		return &validationError{
-			errType: "minItems",
-			message: fmt.Sprintf("must have length greater than 1 but was %d", len(m)),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minItems",
+			Message:                 fmt.Sprintf("must have length greater than 1 but was %d", len(m)),
+			KeywordLocation:         "/minItems",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/array/minItems",
 		}
 	}
 	seen := make(map[string]bool)
 	for i, v := range m {
 		if seen[v] {
-			return &validationError{
-				errType:  "uniqueItems",
-				message:  fmt.Sprintf("items must be unique but %v occurs more than once", v),
-				path:     []interface{}{i},
-				jsonPath: []interface{}{i},
+			return &jsvalidate.ValidationError{
+				ErrType:                 "uniqueItems",
+				Path:                    []interface{}{i},
+				JSONPath:                []interface{}{i},
+				Message:                 fmt.Sprintf("items must be unique but %v occurs more than once", v),
+				KeywordLocation:         "/uniqueItems",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/field_validators/foo/bar.json#/properties/array/uniqueItems",
 			}
 		}
 		seen[v] = true
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
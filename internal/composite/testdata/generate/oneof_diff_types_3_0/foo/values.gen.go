@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
@@ -20,25 +21,44 @@ var (
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json
 func (m *Bar) Validate() error {
+	switch m.Value.(type) {
+	case nil, Baz, Bazes, string, float64, bool:
+	default:
+		return &jsvalidate.ValidationError{
+			ErrType:                 "oneOf",
+			Message:                 fmt.Sprintf("unsupported type: %T", m.Value),
+			KeywordLocation:         "/oneOf",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf",
+		}
+	}
 	if v, ok := m.Value.(float64); ok {
 		if v < 3.7 {
-			return &validationError{
-				errType: "minimum",
-				message: fmt.Sprintf("must be greater than or equal to 3.7 but was %v", v),
+			return &jsvalidate.ValidationError{
+				ErrType:                 "minimum",
+				Message:                 fmt.Sprintf("must be greater than or equal to 3.7 but was %v", v),
+				KeywordLocation:         "/oneOf/3/minimum",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/3/minimum",
 			}
 		}
 	}
 	if v, ok := m.Value.(string); ok {
 		if !barValuePattern.MatchString(v) {
-			return &validationError{
-				errType: "pattern",
-				message: fmt.Sprintf(`must match '^[0-9]{22}$' but got %q`, v),
+			return &jsvalidate.ValidationError{
+				ErrType:                 "pattern",
+				Message:                 fmt.Sprintf(`must match '^[0-9]{22}$' but got %q`, v),
+				KeywordLocation:         "/oneOf/2/pattern",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/2/pattern",
 			}
 		}
 	}
-	if v, ok := m.Value.(interface{ Validate() error }); ok {
+	switch v := m.Value.(type) {
+	case Baz:
 		if err := v.Validate(); err != nil {
-			return err
+			return jsvalidate.Prefix(err, nil, nil, "/oneOf/0")
+		}
+	case Bazes:
+		if err := v.Validate(); err != nil {
+			return jsvalidate.Prefix(err, nil, nil, "/oneOf/1")
 		}
 	}
 	return nil
@@ -99,54 +119,115 @@ func (m *Bar) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.Value)
 }
 
-// Baz is generated from https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/0
-type Baz struct {
-	Baz *string `json:"baz,omitempty"`
+// BarKind identifies the type of value held by a Bar
+type BarKind int
+
+const (
+	// BarKindNull is the kind of a Bar holding null, or no value
+	BarKindNull BarKind = iota
+	// BarKindBaz is the kind of a Bar holding a value of type Baz
+	BarKindBaz
+	// BarKindBazes is the kind of a Bar holding a value of type Bazes
+	BarKindBazes
+	// BarKindString is the kind of a Bar holding a value of type string
+	BarKindString
+	// BarKindFloat64 is the kind of a Bar holding a value of type float64
+	BarKindFloat64
+	// BarKindBool is the kind of a Bar holding a value of type bool
+	BarKindBool
+)
+
+// Kind returns the type of value held
+func (m *Bar) Kind() BarKind {
+	switch m.Value.(type) {
+	case Baz:
+		return BarKindBaz
+	case Bazes:
+		return BarKindBazes
+	case string:
+		return BarKindString
+	case float64:
+		return BarKindFloat64
+	case bool:
+		return BarKindBool
+	}
+	return BarKindNull
 }
 
-// Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/0
-func (m *Baz) Validate() error {
-	return nil
+// IsNull returns whether the value is null
+func (m *Bar) IsNull() bool {
+	return m.Value == nil
 }
 
-// Bazes is generated from https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/1
-type Bazes []string
+// AsBaz returns the value and true if it is of type Baz
+func (m *Bar) AsBaz() (Baz, bool) {
+	v, ok := m.Value.(Baz)
+	return v, ok
+}
 
-// Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/1
-func (m Bazes) Validate() error {
-	return nil
+// BarFromBaz returns a Bar holding the provided value
+func BarFromBaz(v Baz) *Bar {
+	return &Bar{Value: v}
+}
+
+// AsBazes returns the value and true if it is of type Bazes
+func (m *Bar) AsBazes() (Bazes, bool) {
+	v, ok := m.Value.(Bazes)
+	return v, ok
 }
 
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
+// BarFromBazes returns a Bar holding the provided value
+func BarFromBazes(v Bazes) *Bar {
+	return &Bar{Value: v}
 }
 
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
+// AsString returns the value and true if it is of type string
+func (m *Bar) AsString() (string, bool) {
+	v, ok := m.Value.(string)
+	return v, ok
 }
 
-func (e *validationError) ErrType() string {
-	return e.errType
+// BarFromString returns a Bar holding the provided value
+func BarFromString(v string) *Bar {
+	return &Bar{Value: v}
 }
 
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
+// AsFloat64 returns the value and true if it is of type float64
+func (m *Bar) AsFloat64() (float64, bool) {
+	v, ok := m.Value.(float64)
+	return v, ok
 }
 
-func (e *validationError) Path() []interface{} {
-	return e.path
+// BarFromFloat64 returns a Bar holding the provided value
+func BarFromFloat64(v float64) *Bar {
+	return &Bar{Value: v}
 }
 
-func (e *validationError) Message() string {
-	return e.message
+// AsBool returns the value and true if it is of type bool
+func (m *Bar) AsBool() (bool, bool) {
+	v, ok := m.Value.(bool)
+	return v, ok
 }
 
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
+// BarFromBool returns a Bar holding the provided value
+func BarFromBool(v bool) *Bar {
+	return &Bar{Value: v}
 }
 
-var _ valErr = new(validationError)
+// Baz is generated from https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/0
+type Baz struct {
+	Baz *string `json:"baz,omitempty"`
+}
+
+// Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/0
+func (m *Baz) Validate() error {
+	return nil
+}
+
+// Bazes is generated from https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/1
+type Bazes []string
+
+// Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_diff_types_3_0/foo/bar.json#/oneOf/1
+func (m Bazes) Validate() error {
+	return nil
+}
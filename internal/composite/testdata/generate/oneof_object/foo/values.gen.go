@@ -4,6 +4,7 @@ package foo
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/oneof_object/foo/example.json
@@ -52,11 +53,13 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_object/foo/example.json#/oneOf/0
 func (m *Left) Validate() error {
 	if m.Direction != nil && !leftDirectionEnum[*m.Direction] {
-		return &validationError{
-			errType:  "enum",
-			path:     []interface{}{"Direction"},
-			jsonPath: []interface{}{"direction"},
-			message:  fmt.Sprintf(`must be "l" but got %v`, *m.Direction),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "enum",
+			Path:                    []interface{}{"Direction"},
+			JSONPath:                []interface{}{"direction"},
+			Message:                 fmt.Sprintf(`must be "l" but got %v`, *m.Direction),
+			KeywordLocation:         "/properties/direction/enum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/oneof_object/foo/example.json#/oneOf/0/properties/direction/enum",
 		}
 	}
 	return nil
@@ -75,46 +78,14 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/oneof_object/foo/example.json#/oneOf/1
 func (m *Right) Validate() error {
 	if m.Direction != nil && !rightDirectionEnum[*m.Direction] {
-		return &validationError{
-			errType:  "enum",
-			path:     []interface{}{"Direction"},
-			jsonPath: []interface{}{"direction"},
-			message:  fmt.Sprintf(`must be "r" but got %v`, *m.Direction),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "enum",
+			Path:                    []interface{}{"Direction"},
+			JSONPath:                []interface{}{"direction"},
+			Message:                 fmt.Sprintf(`must be "r" but got %v`, *m.Direction),
+			KeywordLocation:         "/properties/direction/enum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/oneof_object/foo/example.json#/oneOf/1/properties/direction/enum",
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -2,7 +2,7 @@
 package foo
 
 import (
-	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // A is generated from https://example.com/testdata/generate/simple_nested/foo/a.json
@@ -13,23 +13,17 @@ type A struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/simple_nested/foo/a.json
 func (m *A) Validate() error {
 	if m.B == nil {
-		return &validationError{
-			errType:  "required",
-			message:  "field required",
-			path:     []interface{}{"B"},
-			jsonPath: []interface{}{"b"},
+		return &jsvalidate.ValidationError{
+			ErrType:                 "required",
+			Path:                    []interface{}{"B"},
+			JSONPath:                []interface{}{"b"},
+			Message:                 "field required",
+			KeywordLocation:         "/required",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/simple_nested/foo/a.json#/required",
 		}
 	}
 	if err := m.B.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"B"}, err.Path()...),
-				jsonPath: append([]interface{}{"b"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "B", "b", "/properties/b")
 	}
 	return nil
 }
@@ -42,37 +36,3 @@ type AB struct {
 func (m *AB) Validate() error {
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
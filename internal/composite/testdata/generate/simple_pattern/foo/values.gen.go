@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
@@ -19,46 +20,14 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/simple_pattern/foo/bar.json
 func (m *Bar) Validate() error {
 	if m.Name != nil && !barNamePattern.MatchString(*m.Name) {
-		return &validationError{
-			errType:  "pattern",
-			path:     []interface{}{"Name"},
-			jsonPath: []interface{}{"name"},
-			message:  fmt.Sprintf(`must match '^[0-9]+$' but got %q`, *m.Name),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "pattern",
+			Path:                    []interface{}{"Name"},
+			JSONPath:                []interface{}{"name"},
+			Message:                 fmt.Sprintf(`must match '^[0-9]+$' but got %q`, *m.Name),
+			KeywordLocation:         "/properties/name/pattern",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/simple_pattern/foo/bar.json#/properties/name/pattern",
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -30,47 +30,67 @@ var (
 func (m *{{ $.Type.Name }}) Validate() error {
 {{ range .Required -}}
 	if {{ .TestSetExpr false }} {
-		return &validationError{
-			errType: "required",
-			message: "field required",
-			path: []interface{}{"{{ .Name }}"},
-			jsonPath: []interface{}{"{{ .JSONName }}"},
-		}
+		return {{ $.ValidationError "required" ` + "`" + `"field required"` + "`" + ` (printf "%q" .Name) (printf "%q" .JSONName) ` + "`" + `"/required"` + "`" + ` ($.AbsoluteLocation "/required") }}
+	}
+{{ end -}}
+{{ with .Union -}}
+	switch m.Value.(type) {
+	case {{ if .Nil }}nil{{ if .Branches }}, {{ end }}{{ end }}{{ range $i, $b := .Branches }}{{ if $i }}, {{ end }}{{ $.QualName $b.Type }}{{ end }}:
+	default:
+		return {{ $.ValidationError .Keyword ` + "`" + `fmt.Sprintf("unsupported type: %T", m.Value)` + "`" + ` "" "" (printf "%q" (print "/" .Keyword)) ($.AbsoluteLocation (print "/" .Keyword)) }}
+	}
+{{ end -}}
+{{ with .Sealed -}}
+	if m.Value == nil {
+		return {{ $.ValidationError "oneOf" ` + "`" + `"matches none of the oneOf schemas"` + "`" + ` "" "" ` + "`" + `"/oneOf"` + "`" + ` ($.AbsoluteLocation "/oneOf") }}
 	}
 {{ end -}}
 {{ range $Field := .Fields -}}
 {{ if ne .Type.Name "interface{}" -}}
 {{ range $Field.Validators -}}
-{{ if eq .Name "subschema" -}}
-    {{ if and (not $Field.Required) $Field.Type.Pointer -}}if {{ $Field.TestSetExpr true }} { {{ end -}}
-    if err := m.{{ $Field.FieldRef }}.Validate(); err != nil {
-		{{ if not $Field.Embedded -}}
-		if err, ok := err.(valErr); ok {
-        	return &validationError{
-        		errType: err.ErrType(),
-        		message: err.Message(),
-				path: append([]interface{}{"{{ $Field.Name }}"}, err.Path()...),
-				jsonPath: append([]interface{}{"{{ $Field.JSONName }}"}, err.JSONPath()...),
-			}
+{{ if and (eq .Name "subschema") $Field.Sealed -}}
+	switch v := m.{{ $Field.FieldRef }}.(type) {
+{{ range $i, $b := $.Sealed.Branches -}}
+	case *{{ $.QualName $b }}:
+		if err := v.Validate(); err != nil {
+			return {{ $.ErrorPrefix }}(err, nil, nil, {{ printf "%q" (index $.Sealed.Locations $i) }})
 		}
-		{{ end -}}
+{{ end -}}
+	}
+{{ else if eq .Name "subschema" -}}
+    {{ with $Field.SubschemaGuard -}}if {{ . }} { {{ end -}}
+    if err := m.{{ $Field.ValueRef }}.Validate(); err != nil {
+		{{ if $Field.Embedded -}}
 		return err
+		{{ else -}}
+		return {{ $.ErrorPrefix }}(err, "{{ $Field.Name }}", "{{ $Field.JSONName }}", {{ .KeywordLocation $Field.KeywordPrefix }})
+		{{ end -}}
 	}
-	{{- if and (not $Field.Required) $Field.Type.Pointer -}}} {{- end }}
+	{{- if $Field.SubschemaGuard -}}} {{- end }}
 {{ else -}}
-    if {{ if not $Field.Required -}}{{ $Field.TestSetExpr true }} &&{{ end -}}{{ .Test ($Field.NameSpace) ($Field.DerefExpr) }} {
-		return &validationError{
-    		errType: "{{ .Name }}",
-			path: []interface{}{"{{ $Field.Name }}"},
-			jsonPath: []interface{}{"{{ $Field.JSONName }}"},
-			message: fmt.Sprintf({{ .Sprintf ($Field.NameSpace) ($Field.DerefExpr) }}),
-		}
+    if {{ $Field.Guard . }}{{ .Test ($Field.NameSpace) ($Field.Operand .) }} {
+		return {{ $.ValidationError .Name (print "fmt.Sprintf(" (.Sprintf ($Field.NameSpace) ($Field.Operand .)) ")") (printf "%q" $Field.Name) (printf "%q" $Field.JSONName) (.KeywordLocation $Field.KeywordPrefix) .AbsoluteKeywordLocation }}
 	}
 {{ end -}}
 {{ end -}}
 {{ else -}}
 {{ range $Field.Validators -}}
-{{ if eq .Name "subschema" -}}
+{{ if and (eq .Name "subschema") $.Union -}}
+	switch v := m.{{ $Field.FieldRef }}.(type) {
+{{ range $.Union.Branches -}}
+{{ if not .Type.BuiltIn -}}
+	case {{ $.QualName .Type }}:
+		if err := v.Validate(); err != nil {
+{{ if .Location -}}
+			return {{ $.ErrorPrefix }}(err, nil, nil, {{ printf "%q" .Location }})
+{{ else -}}
+			return err
+{{ end -}}
+		}
+{{ end -}}
+{{ end -}}
+	}
+{{ else if eq .Name "subschema" -}}
 	if v, ok := m.{{ $Field.FieldRef }}.(interface { Validate() error }); ok {
 		if err := v.Validate(); err != nil {
 			return err
@@ -79,10 +99,7 @@ func (m *{{ $.Type.Name }}) Validate() error {
 {{ else -}}
 	if v, ok := m.{{ $Field.FieldRef }}.({{ .ImpliedType }}); ok {
 		if {{ .Test ($Field.NameSpace) "v" }} {
-			return &validationError{
-				errType: "{{ .Name }}",
-				message: fmt.Sprintf({{ .Sprintf ($Field.NameSpace) "v" }}),
-			}
+			return {{ $.ValidationError .Name (print "fmt.Sprintf(" (.Sprintf ($Field.NameSpace) "v") ")") "" "" (.KeywordLocation .Location) .AbsoluteKeywordLocation }}
 		}
 	}
 {{ end -}}
@@ -92,9 +109,28 @@ func (m *{{ $.Type.Name }}) Validate() error {
 	return nil
 }
 
+{{ if .ApplyDefaults -}}
+// New{{ .Type.Name }} returns a new {{ .Type.Name }} with the default values defined in {{ .ID }} applied
+func New{{ .Type.Name }}() (*{{ .Type.Name }}, error) {
+	m := new({{ .Type.Name }})
+	if err := m.ApplyDefaults(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ApplyDefaults sets any unset fields to the default values defined in {{ .ID }}, including those of nested values, returning an error if one fails to decode
+func (m *{{ .Type.Name }}) ApplyDefaults() error {
+{{ range .Fields -}}
+{{ .ApplyDefaults -}}
+{{ end -}}
+	return nil
+}
+
+{{ end -}}
 {{ range $t := .Traits -}}
 {{ if eq .Template "boxed" }}
-func (m *{{ $.Type.Name }}) MarshalJSON() ([]byte, error) {
+func (m {{ $.Type.Name }}) MarshalJSON() ([]byte, error) {
     inner := struct {
 {{ range $.Fields -}}
 {{ .InnerFieldDecl }}
@@ -114,6 +150,47 @@ func (m *{{ $.Type.Name }}) MarshalJSON() ([]byte, error) {
 	return json.Marshal(inner)
 }
 
+{{ else if eq .Template "requiredKeys" }}
+func (m *{{ $.Type.Name }}) UnmarshalJSON(data []byte) error {
+{{ with $.RequiredKeys -}}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	if keys == nil { // null
+		return nil
+	}
+	// required values are always set, so their absence can only be detected here
+{{ range . -}}
+	if _, ok := keys["{{ .JSONName }}"]; !ok {
+		return {{ $.ValidationError "required" ` + "`" + `"field required"` + "`" + ` (printf "%q" .Name) (printf "%q" .JSONName) ` + "`" + `"/required"` + "`" + ` ($.AbsoluteLocation "/required") }}
+	}
+{{ end -}}
+{{ end -}}
+	type plain {{ $.Type.Name }}
+{{ with $.ContentFields -}}
+	// the documents are encoded as strings, decoded by the fields shadowing those holding them
+	var content struct {
+		*plain
+{{ range . -}}
+		{{ .Name }} *string {{ .Tag }}
+{{ end -}}
+	}
+	content.plain = (*plain)(m)
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+{{ range . -}}
+	if content.{{ .Name }} != nil {
+		m.{{ .Name }} = json.RawMessage(*content.{{ .Name }})
+	}
+{{ end -}}
+	return nil
+{{ else -}}
+	return json.Unmarshal(data, (*plain)(m))
+{{ end -}}
+}
+
 {{ else if eq .Template "discriminator" }}
 func (m *{{ $.Type.Name }}) UnmarshalJSON(data []byte) error {
 	var discrim struct {
@@ -143,6 +220,53 @@ func (m *{{ $.Type.Name }}) UnmarshalJSON(data []byte) error {
 func (m *{{ $.Type.Name }}) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.{{ .StructField.Name }})
 }
+{{ else if eq .Template "sealedOneOf" }}
+{{- /*gotype: github.com/ns1/jsonschema2go.sealedOneOfTrait */}}
+// {{ .Interface.Name }} is a value which a {{ $.Type.Name }} may hold: one of
+{{- range $i, $b := .Branches }}{{ if $i }},{{ end }} *{{ $.QualName $b }}{{ end }}
+type {{ .Interface.Name }} interface {
+	Validate() error
+	{{ .Seal }}()
+}
+{{ range .Branches }}
+func (*{{ $.QualName . }}) {{ $t.Seal }}() {}
+{{ end }}
+func (m *{{ $.Type.Name }}) UnmarshalJSON(data []byte) error {
+	tok, err := json.NewDecoder(bytes.NewReader(data)).Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("unsupported type: %T", tok)
+	}
+	var (
+		matches  []{{ .Interface.Name }}
+		failures []string
+	)
+	for _, v := range []{{ .Interface.Name }}{ {{- range $i, $b := .Branches }}{{ if $i }}, {{ end }}new({{ $.QualName $b }}){{ end -}} } {
+		err := json.Unmarshal(data, v)
+		if err == nil {
+			err = v.Validate()
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%T: %v", v, err))
+			continue
+		}
+		matches = append(matches, v)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("matches none of the oneOf schemas: %s", strings.Join(failures, "; "))
+	case 1:
+		m.Value = matches[0]
+		return nil
+	}
+	return fmt.Errorf("matches more than one of the oneOf schemas: %T and %T", matches[0], matches[1])
+}
+
+func (m *{{ $.Type.Name }}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Value)
+}
 {{ else if eq .Template "oneOf" }}
 func (m *{{ $.Type.Name }}) UnmarshalJSON(data []byte) error {
 	tok, err := json.NewDecoder(bytes.NewReader(data)).Token()
@@ -221,6 +345,47 @@ func (m *{{ $.Type.Name }}) UnmarshalJSON(data []byte) error {
 func (m *{{ $.Type.Name }}) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.Value)
 }
+
+// {{ $.Type.Name }}Kind identifies the type of value held by a {{ $.Type.Name }}
+type {{ $.Type.Name }}Kind int
+
+const (
+	// {{ $.Type.Name }}KindNull is the kind of a {{ $.Type.Name }} holding null, or no value
+	{{ $.Type.Name }}KindNull {{ $.Type.Name }}Kind = iota
+{{ range .Branches -}}
+	// {{ $.Type.Name }}Kind{{ .Name }} is the kind of a {{ $.Type.Name }} holding a value of type {{ $.QualName .Type }}
+	{{ $.Type.Name }}Kind{{ .Name }}
+{{ end -}}
+)
+
+// Kind returns the type of value held
+func (m *{{ $.Type.Name }}) Kind() {{ $.Type.Name }}Kind {
+	switch m.Value.(type) {
+{{ range .Branches -}}
+	case {{ $.QualName .Type }}:
+		return {{ $.Type.Name }}Kind{{ .Name }}
+{{ end -}}
+	}
+	return {{ $.Type.Name }}KindNull
+}
+{{ if .Nil }}
+// IsNull returns whether the value is null
+func (m *{{ $.Type.Name }}) IsNull() bool {
+	return m.Value == nil
+}
+{{ end -}}
+{{ range .Branches }}
+// As{{ .Name }} returns the value and true if it is of type {{ $.QualName .Type }}
+func (m *{{ $.Type.Name }}) As{{ .Name }}() ({{ $.QualName .Type }}, bool) {
+	v, ok := m.Value.({{ $.QualName .Type }})
+	return v, ok
+}
+
+// {{ $.Type.Name }}From{{ .Name }} returns a {{ $.Type.Name }} holding the provided value
+func {{ $.Type.Name }}From{{ .Name }}(v {{ $.QualName .Type }}) *{{ $.Type.Name }} {
+	return &{{ $.Type.Name }}{Value: v}
+}
+{{ end -}}
 {{ end -}}
 {{ end -}}
 
@@ -0,0 +1,98 @@
+package crawl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// TypeCollisionError is returned when schemas with different IDs would generate Go types with the same name in the same
+// package, which would fail to compile
+type TypeCollisionError struct {
+	Collisions []TypeCollision
+}
+
+// TypeCollision is a Go type, or another identifier declared alongside one, which would be generated from more than one
+// schema
+type TypeCollision struct {
+	Type    gen.TypeInfo
+	Schemas []*gen.Schema // sorted by ID
+}
+
+func (e *TypeCollisionError) Error() string {
+	var b strings.Builder
+	for i, c := range e.Collisions {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("type ")
+		b.WriteString(c.Type.GoPath)
+		b.WriteString(".")
+		b.WriteString(c.Type.Name)
+		b.WriteString(" would be generated from more than one schema:")
+		for _, s := range c.Schemas {
+			b.WriteString("\n\t")
+			b.WriteString(s.Location())
+			b.WriteString(" (")
+			b.WriteString(s.String())
+			b.WriteString(")")
+		}
+	}
+	return b.String()
+}
+
+// Disambiguate returns a typer which keeps the name of the type generated from the first schema of each collision and
+// renames the types generated from the others with the provided function, where n counts the renamed schemas of the
+// collision from 1
+func (e *TypeCollisionError) Disambiguate(
+	typer planning.Typer,
+	rename func(t gen.TypeInfo, schema *gen.Schema, n int) string,
+) planning.Typer {
+	names := make(map[string]string, len(typer.Names))
+	for id, name := range typer.Names {
+		names[id] = name
+	}
+	for _, c := range e.Collisions {
+		for n, s := range c.Schemas[1:] {
+			names[s.String()] = rename(c.Type, s, n+1)
+		}
+	}
+	typer.Names = names
+	return typer
+}
+
+// TypeCollisions returns the Go types which would be generated from more than one schema, including the identifiers
+// declared alongside a type, such as the kinds of a union, which would collide with another type or identifier
+func (g *Graph) TypeCollisions() []TypeCollision {
+	byType := make(map[gen.TypeInfo][]*gen.Schema)
+	for _, n := range g.Nodes {
+		if s, ok := g.schemas[n.ID]; ok {
+			t := gen.TypeInfo{GoPath: n.GoPath, Name: n.Name}
+			byType[t] = append(byType[t], s)
+			for _, d := range g.decls[n.ID] {
+				t := gen.TypeInfo{GoPath: n.GoPath, Name: d}
+				byType[t] = append(byType[t], s)
+			}
+		}
+	}
+
+	var collisions []TypeCollision
+	for t, schemas := range byType {
+		if len(schemas) < 2 {
+			continue
+		}
+		sort.Slice(schemas, func(i, j int) bool {
+			return schemas[i].String() < schemas[j].String()
+		})
+		collisions = append(collisions, TypeCollision{Type: t, Schemas: schemas})
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		if collisions[i].Type.GoPath != collisions[j].Type.GoPath {
+			return collisions[i].Type.GoPath < collisions[j].Type.GoPath
+		}
+		return collisions[i].Type.Name < collisions[j].Type.Name
+	})
+	return collisions
+}
@@ -0,0 +1,116 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+func TestCrawl_typeCollision(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := filepath.Abs("testdata/collision")
+	r.NoError(err)
+	uris := []string{"file:" + filepath.Join(dir, "root.json")}
+
+	_, err = Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	var collisionErr *TypeCollisionError
+	r.True(errors.As(err, &collisionErr), "expected a type collision error but got %v", err)
+	r.EqualError(err, `type example.com/collision.RootBaz would be generated from more than one schema:
+	`+dir+`/root.json:5:12#/properties/baz (https://example.com/collision/root.json#/properties/baz)
+	`+dir+`/root_baz.json:1:1# (https://example.com/collision/root_baz.json)`)
+
+	grouped, err := Crawl(
+		context.Background(),
+		planning.Composite,
+		gen.NewLoader(),
+		collisionErr.Disambiguate(planning.DefaultTyper, func(t gen.TypeInfo, schema *gen.Schema, n int) string {
+			return t.Name + strconv.Itoa(n+1)
+		}),
+		uris,
+	)
+	r.NoError(err)
+
+	var got []string
+	for _, pl := range grouped["example.com/collision"] {
+		got = append(got, pl.Type().Name)
+	}
+	sort.Strings(got)
+	r.Equal([]string{"Root", "RootBaz", "RootBaz2"}, got)
+}
+
+func TestCrawl_declCollision(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := filepath.Abs("testdata/collision_decl")
+	r.NoError(err)
+	uris := []string{"file:" + filepath.Join(dir, "root.json")}
+
+	// the kind type declared alongside the union RootID collides with the type of the property idKind
+	_, err = Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	var collisionErr *TypeCollisionError
+	r.True(errors.As(err, &collisionErr), "expected a type collision error but got %v", err)
+	r.EqualError(err, `type example.com/collision_decl.RootIDKind would be generated from more than one schema:
+	`+dir+`/root.json:5:11#/properties/id (https://example.com/collision_decl/root.json#/properties/id)
+	`+dir+`/root.json:6:15#/properties/idKind (https://example.com/collision_decl/root.json#/properties/idKind)`)
+
+	grouped, err := Crawl(
+		context.Background(),
+		planning.Composite,
+		gen.NewLoader(),
+		collisionErr.Disambiguate(planning.DefaultTyper, func(t gen.TypeInfo, schema *gen.Schema, n int) string {
+			return t.Name + strconv.Itoa(n+1)
+		}),
+		uris,
+	)
+	r.NoError(err)
+
+	var got []string
+	for _, pl := range grouped["example.com/collision_decl"] {
+		got = append(got, pl.Type().Name)
+	}
+	sort.Strings(got)
+	r.Equal([]string{"Root", "RootID", "RootIDKind2"}, got)
+}
+
+func TestCrawl_constructorCollision(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := filepath.Abs("testdata/collision_new")
+	r.NoError(err)
+	uris := []string{"file:" + filepath.Join(dir, "bar.json")}
+
+	// the constructor NewBar declared for Bar, which has a default, collides with the type of new_bar.json
+	_, err = Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	var collisionErr *TypeCollisionError
+	r.True(errors.As(err, &collisionErr), "expected a type collision error but got %v", err)
+	r.EqualError(err, `type example.com/collision_new.NewBar would be generated from more than one schema:
+	`+dir+`/bar.json:1:1# (https://example.com/collision_new/bar.json)
+	`+dir+`/new_bar.json:1:1# (https://example.com/collision_new/new_bar.json)`)
+
+	grouped, err := Crawl(
+		context.Background(),
+		planning.Composite,
+		gen.NewLoader(),
+		collisionErr.Disambiguate(planning.DefaultTyper, func(t gen.TypeInfo, schema *gen.Schema, n int) string {
+			return t.Name + strconv.Itoa(n+1)
+		}),
+		uris,
+	)
+	r.NoError(err)
+
+	var got []string
+	for _, pl := range grouped["example.com/collision_new"] {
+		got = append(got, pl.Type().Name)
+	}
+	sort.Strings(got)
+	r.Equal([]string{"Bar", "NewBar2"}, got)
+}
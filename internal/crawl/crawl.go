@@ -9,6 +9,9 @@ import (
 	"net/url"
 )
 
+// Crawl plans the schemas at the provided URIs along with every schema they depend on, grouping the plans by Go
+// path. If schemas with different IDs would generate the same Go type, a *TypeCollisionError is returned; if the
+// generated packages would import each other, an *ImportCycleError is returned.
 func Crawl(
 	ctx context.Context,
 	planner gen.Planner,
@@ -16,6 +19,34 @@ func Crawl(
 	typer planning.Typer,
 	uris []string,
 ) (map[string][]gen.Plan, error) {
+	schemas, err := load(ctx, loader, uris)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGraph()
+	plans, err := crawl(ctx, loader, typer, planner, schemas, g)
+	if err != nil {
+		return nil, err
+	}
+	g.finish()
+	if collisions := g.TypeCollisions(); len(collisions) > 0 {
+		return nil, &TypeCollisionError{Collisions: collisions}
+	}
+	if cycles := g.ImportCycles(); len(cycles) > 0 {
+		return nil, &ImportCycleError{Cycles: cycles}
+	}
+
+	resolveDefaults(plans)
+
+	grouped := make(map[string][]gen.Plan)
+	for _, p := range plans {
+		grouped[p.Type().GoPath] = append(grouped[p.Type().GoPath], p)
+	}
+	return grouped, nil
+}
+
+func load(ctx context.Context, loader gen.Loader, uris []string) ([]*gen.Schema, error) {
 	var schemas []*gen.Schema
 	for _, uri := range uris {
 		u, err := url.Parse(uri)
@@ -28,17 +59,7 @@ func Crawl(
 		}
 		schemas = append(schemas, sch)
 	}
-
-	plans, err := crawl(ctx, loader, typer, planner, schemas)
-	if err != nil {
-		return nil, err
-	}
-
-	grouped := make(map[string][]gen.Plan)
-	for _, p := range plans {
-		grouped[p.Type().GoPath] = append(grouped[p.Type().GoPath], p)
-	}
-	return grouped, nil
+	return schemas, nil
 }
 
 func crawl(
@@ -47,6 +68,7 @@ func crawl(
 	typer planning.Typer,
 	planner gen.Planner,
 	schemas []*gen.Schema,
+	graph *Graph, // if set, records every schema reached and its dependencies
 ) ([]gen.Plan, error) {
 	{
 		q := make([]*gen.Schema, len(schemas))
@@ -60,25 +82,44 @@ func crawl(
 		s := schemas[0]
 		schemas = schemas[1:]
 
-		k := s.ID.String()
+		k := s.String()
 		if seen[k] {
 			continue
 		}
 		seen[k] = true
 
 		if s.Config.Exclude {
+			if graph != nil {
+				t, _ := typer.TypeInfo(s)
+				graph.addNode(&Node{ID: k, GoPath: t.GoPath, Name: t.Name, Excluded: true})
+			}
 			continue
 		}
 
 		helper := &SimpleHelper{loader, typer, nil}
 		p, err := planner.Plan(ctx, helper, s)
-		if err != nil {
-			return nil, fmt.Errorf("unable to plan %v: %w", s, err)
+		var planErr *gen.PlanError
+		switch {
+		case errors.As(err, &planErr):
+			return nil, err
+		case err != nil:
+			return nil, fmt.Errorf("%v: unable to plan %v: %w", s.Location(), s, err)
 		}
 		if p == nil {
 			return nil, fmt.Errorf("received a nil plan for %v", s)
 		}
 
+		if graph != nil {
+			graph.addNode(&Node{ID: k, GoPath: p.Type().GoPath, Name: p.Type().Name})
+			graph.schemas[k] = s
+			if d, ok := p.(gen.DeclsPlan); ok {
+				graph.decls[k] = d.Decls()
+			}
+			for _, d := range helper.deps {
+				graph.addEdge(k, d.String())
+			}
+		}
+
 		schemas = append(schemas, helper.deps...)
 		plans = append(plans, p)
 	}
@@ -97,6 +138,10 @@ func (h *SimpleHelper) Dep(ctx context.Context, schemas ...*gen.Schema) error {
 	return nil
 }
 
+// Deps returns the schemas planners have reported as dependencies, in the order reported
+func (h *SimpleHelper) Deps() []*gen.Schema {
+	return h.deps
+}
 
 func (h *SimpleHelper) DetectGoBaseType(ctx context.Context, schema *gen.Schema) (gen.GoBaseType, error) {
 	if len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
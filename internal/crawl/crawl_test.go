@@ -260,6 +260,7 @@ func TestSchemaToPlan(t *testing.T) {
 				planning.DefaultTyper,
 				planning.Composite,
 				[]*gen.Schema{tt.schema},
+				nil,
 			)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SchemaToPlan() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,166 @@
+package crawl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// ImportCycleError is returned when the types generated from the crawled schemas would place Go packages in an import
+// cycle, which would fail to compile
+type ImportCycleError struct {
+	Cycles []ImportCycle
+}
+
+// ImportCycle is a set of Go packages which would import each other
+type ImportCycle struct {
+	Packages []string
+	Imports  []Import // the dependencies between types in different packages of the cycle
+}
+
+// Import is a dependency of a type on a type in another package
+type Import struct {
+	From, To Node
+}
+
+func (i Import) String() string {
+	return fmt.Sprintf("%s (%s.%s) -> %s (%s.%s)", i.From.ID, i.From.GoPath, i.From.Name, i.To.ID, i.To.GoPath, i.To.Name)
+}
+
+func (e *ImportCycleError) Error() string {
+	var b strings.Builder
+	for i, c := range e.Cycles {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("import cycle between generated packages ")
+		b.WriteString(strings.Join(c.Packages, ", "))
+		b.WriteString(":")
+		for _, i := range c.Imports {
+			b.WriteString("\n\t")
+			b.WriteString(i.String())
+		}
+	}
+	return b.String()
+}
+
+// Merge returns a typer which places the types of every package in a cycle into the lexically first package of the
+// cycle, breaking it. Excluded types stay where they are, since they aren't generated.
+func (e *ImportCycleError) Merge(typer planning.Typer) planning.Typer {
+	relocations := make(map[string]string)
+	for _, c := range e.Cycles {
+		for _, p := range c.Packages {
+			relocations[p] = c.Packages[0]
+		}
+	}
+	typeFunc := typer.TypeFunc
+	typer.TypeFunc = func(schema *gen.Schema) gen.TypeInfo {
+		t := typeFunc(schema)
+		if schema.Config.Exclude {
+			return t
+		}
+		if goPath, ok := relocations[t.GoPath]; ok {
+			t.GoPath = goPath
+		}
+		return t
+	}
+	return typer
+}
+
+// ImportCycles returns the sets of Go packages which would import each other, found as the strongly connected
+// components of the package graph
+func (g *Graph) ImportCycles() []ImportCycle {
+	imports := make(map[string][]string)
+	var packages []string
+	seen := make(map[string]bool)
+	addPkg := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			packages = append(packages, p)
+		}
+	}
+	for _, e := range g.Edges {
+		from, to := g.nodes[e.From], g.nodes[e.To]
+		if !e.CrossPackage || from.GoPath == "" || to.GoPath == "" {
+			continue
+		}
+		addPkg(from.GoPath)
+		addPkg(to.GoPath)
+		imports[from.GoPath] = append(imports[from.GoPath], to.GoPath)
+	}
+	sort.Strings(packages)
+
+	var cycles []ImportCycle
+	for _, component := range stronglyConnected(packages, imports) {
+		if len(component) < 2 {
+			continue
+		}
+		sort.Strings(component)
+		in := make(map[string]bool, len(component))
+		for _, p := range component {
+			in[p] = true
+		}
+		c := ImportCycle{Packages: component}
+		for _, e := range g.Edges {
+			if from, to := g.nodes[e.From], g.nodes[e.To]; e.CrossPackage && in[from.GoPath] && in[to.GoPath] {
+				c.Imports = append(c.Imports, Import{From: *from, To: *to})
+			}
+		}
+		cycles = append(cycles, c)
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].Packages[0] < cycles[j].Packages[0]
+	})
+	return cycles
+}
+
+// stronglyConnected returns the strongly connected components of a directed graph using Tarjan's algorithm
+func stronglyConnected(vertices []string, edges map[string][]string) (components [][]string) {
+	var (
+		index   = make(map[string]int)
+		lowLink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		visit   func(v string)
+	)
+	visit = func(v string) {
+		index[v] = len(index)
+		lowLink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range edges[v] {
+			if _, ok := index[w]; !ok {
+				visit(w)
+				if lowLink[w] < lowLink[v] {
+					lowLink[v] = lowLink[w]
+				}
+			} else if onStack[w] && index[w] < lowLink[v] {
+				lowLink[v] = index[w]
+			}
+		}
+
+		if lowLink[v] == index[v] {
+			var component []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+	for _, v := range vertices {
+		if _, ok := index[v]; !ok {
+			visit(v)
+		}
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+func TestCrawl_importCycle(t *testing.T) {
+	r := require.New(t)
+
+	p, err := filepath.Abs("testdata/cycle/a.json")
+	r.NoError(err)
+	uris := []string{"file:" + p}
+
+	_, err = Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	var cycleErr *ImportCycleError
+	r.True(errors.As(err, &cycleErr), "expected an import cycle error but got %v", err)
+	r.EqualError(err, `import cycle between generated packages example.com/a, example.com/b:
+	https://example.com/cycle/a.json (example.com/a.A) -> https://example.com/cycle/b.json (example.com/b.B)
+	https://example.com/cycle/b.json (example.com/b.B) -> https://example.com/cycle/a.json (example.com/a.A)`)
+
+	grouped, err := Crawl(
+		context.Background(),
+		planning.Composite,
+		gen.NewLoader(),
+		cycleErr.Merge(planning.DefaultTyper),
+		uris,
+	)
+	r.NoError(err)
+
+	got := make(map[string][]string)
+	for goPath, plans := range grouped {
+		for _, pl := range plans {
+			got[goPath] = append(got[goPath], pl.Type().Name)
+		}
+		sort.Strings(got[goPath])
+	}
+	r.Equal(map[string][]string{"example.com/a": {"A", "B"}, "example.com/c": {"C"}}, got)
+}
+
+func TestCrawl_importCycleExcluded(t *testing.T) {
+	r := require.New(t)
+
+	p, err := filepath.Abs("testdata/cycle_excluded/a.json")
+	r.NoError(err)
+	uris := []string{"file:" + p}
+
+	_, err = Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	var cycleErr *ImportCycleError
+	r.True(errors.As(err, &cycleErr), "expected an import cycle error but got %v", err)
+
+	g, err := CrawlGraph(
+		context.Background(),
+		planning.Composite,
+		gen.NewLoader(),
+		cycleErr.Merge(planning.DefaultTyper),
+		uris,
+	)
+	r.NoError(err)
+	r.Empty(g.ImportCycles())
+
+	const (
+		a   = "https://example.com/cycle_excluded/a.json"
+		b   = "https://example.com/cycle_excluded/b.json"
+		ext = a + "#/properties/ext"
+	)
+	r.Equal([]*Node{
+		{ID: a, GoPath: "example.com/a", Name: "A"},
+		{ID: ext, GoPath: "example.com/b", Name: "Ext", Excluded: true},
+		{ID: b, GoPath: "example.com/a", Name: "B"},
+	}, g.Nodes)
+}
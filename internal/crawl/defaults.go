@@ -0,0 +1,40 @@
+package crawl
+
+import (
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// resolveDefaults determines which of the planned types apply default values, either their own or those of the values
+// they hold, and records the result on each plan. Types which weren't planned, such as excluded ones, never do.
+func resolveDefaults(plans []gen.Plan) {
+	key := func(t gen.TypeInfo) gen.TypeInfo {
+		return gen.TypeInfo{GoPath: t.GoPath, Name: t.Name}
+	}
+
+	applies := make(map[gen.TypeInfo]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, p := range plans {
+			d, ok := p.(gen.DefaultsPlan)
+			if !ok || applies[key(p.Type())] {
+				continue
+			}
+			own, held := d.Defaults()
+			for _, t := range held {
+				own = own || applies[key(t)]
+			}
+			if own {
+				applies[key(p.Type())] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, p := range plans {
+		if d, ok := p.(gen.DefaultsPlan); ok {
+			d.SetDefaults(func(t gen.TypeInfo) bool {
+				return applies[key(t)]
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package crawl
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ns1/jsonschema2go/internal/composite"
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+func TestCrawl_defaults(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := filepath.Abs("testdata/defaults")
+	r.NoError(err)
+	uris := []string{"file:" + filepath.Join(dir, "root.json")}
+
+	grouped, err := Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	r.NoError(err)
+
+	nested := make(map[string]bool)
+	for _, p := range grouped["example.com/defaults"] {
+		for _, f := range p.(*composite.StructPlan).Fields {
+			nested[p.Type().Name+"."+f.Name] = f.NestedDefaults
+		}
+	}
+	r.Equal(map[string]bool{
+		"Root.Outer":             true,
+		"Root.Plain":             false,
+		"RootOuter.Inner":        true,
+		"RootOuterInner.Enabled": false,
+		"RootPlain.Name":         false,
+	}, nested)
+}
+
+func TestCrawl_invalidDefault(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := filepath.Abs("testdata/defaults")
+	r.NoError(err)
+	uris := []string{"file:" + filepath.Join(dir, "invalid.json")}
+
+	_, err = Crawl(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, uris)
+	r.Error(err)
+	r.Contains(err.Error(), `property "count": default [] doesn't match the type of the value`)
+}
@@ -0,0 +1,166 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// Graph is the dependency graph of the schemas reached while crawling and the Go types generated from them
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []Edge  `json:"edges"`
+
+	nodes   map[string]*Node
+	edges   map[Edge]bool
+	schemas map[string]*gen.Schema // the schema of every node which was planned, by ID
+	decls   map[string][]string    // the identifiers declared besides the type of every node which was planned, by ID
+}
+
+// Node is a schema and the Go type generated from it
+type Node struct {
+	ID       string `json:"id"`
+	GoPath   string `json:"goPath,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Excluded bool   `json:"excluded,omitempty"` // the schema is excluded from generation, so it has no plan
+}
+
+// Edge records that the type generated from one schema depends on the type generated from another
+type Edge struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	CrossPackage bool   `json:"crossPackage,omitempty"` // the types are generated in different Go packages
+}
+
+// CrawlGraph crawls the schemas at the provided URIs as Crawl does, returning the dependency graph of the schemas
+// reached rather than their plans.
+func CrawlGraph(
+	ctx context.Context,
+	planner gen.Planner,
+	loader gen.Loader,
+	typer planning.Typer,
+	uris []string,
+) (*Graph, error) {
+	schemas, err := load(ctx, loader, uris)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGraph()
+	if _, err := crawl(ctx, loader, typer, planner, schemas, g); err != nil {
+		return nil, err
+	}
+	g.finish()
+	return g, nil
+}
+
+func newGraph() *Graph {
+	return &Graph{
+		nodes:   make(map[string]*Node),
+		edges:   make(map[Edge]bool),
+		schemas: make(map[string]*gen.Schema),
+		decls:   make(map[string][]string),
+	}
+}
+
+func (g *Graph) addNode(n *Node) {
+	if _, ok := g.nodes[n.ID]; ok {
+		return
+	}
+	g.nodes[n.ID] = n
+	g.Nodes = append(g.Nodes, n)
+}
+
+func (g *Graph) addEdge(from, to string) {
+	e := Edge{From: from, To: to}
+	if g.edges[e] {
+		return
+	}
+	g.edges[e] = true
+	g.Edges = append(g.Edges, e)
+}
+
+// finish marks cross package edges and sorts the graph so that it's written deterministically
+func (g *Graph) finish() {
+	for i, e := range g.Edges {
+		from, to := g.nodes[e.From], g.nodes[e.To]
+		g.Edges[i].CrossPackage = from != nil && to != nil && from.GoPath != to.GoPath
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Types are clustered by Go package, excluded schemas are
+// dashed and edges between packages are bold.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var (
+		packages []string
+		byPkg    = make(map[string][]*Node)
+	)
+	for _, n := range g.Nodes {
+		if _, ok := byPkg[n.GoPath]; !ok {
+			packages = append(packages, n.GoPath)
+		}
+		byPkg[n.GoPath] = append(byPkg[n.GoPath], n)
+	}
+	sort.Strings(packages)
+
+	ew := &errWriter{w: w}
+	ew.printf("digraph schemas {\n")
+	ew.printf("\tnode [shape=box];\n")
+	for i, pkg := range packages {
+		indent := "\t"
+		if pkg != "" {
+			ew.printf("\tsubgraph cluster_%d {\n", i)
+			ew.printf("\t\tlabel=%s;\n", strconv.Quote(pkg))
+			indent = "\t\t"
+		}
+		for _, n := range byPkg[pkg] {
+			label := n.ID
+			if n.Name != "" {
+				label = n.Name + "\n" + n.ID
+			}
+			style := ""
+			if n.Excluded {
+				style = ", style=dashed"
+			}
+			ew.printf("%s%s [label=%s%s];\n", indent, strconv.Quote(n.ID), strconv.Quote(label), style)
+		}
+		if pkg != "" {
+			ew.printf("\t}\n")
+		}
+	}
+	for _, e := range g.Edges {
+		style := ""
+		if e.CrossPackage {
+			style = " [style=bold]"
+		}
+		ew.printf("\t%s -> %s%s;\n", strconv.Quote(e.From), strconv.Quote(e.To), style)
+	}
+	ew.printf("}\n")
+	return ew.err
+}
+
+// errWriter retains the first error encountered while writing
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
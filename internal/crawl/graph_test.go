@@ -0,0 +1,72 @@
+package crawl
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+func TestCrawlGraph(t *testing.T) {
+	r := require.New(t)
+
+	p, err := filepath.Abs("testdata/graph/root.json")
+	r.NoError(err)
+
+	g, err := CrawlGraph(
+		context.Background(),
+		planning.Composite,
+		gen.NewLoader(),
+		planning.DefaultTyper,
+		[]string{"file:" + p},
+	)
+	r.NoError(err)
+
+	const (
+		root     = "https://example.com/graph/root.json"
+		other    = "https://example.com/graph/other.json"
+		excluded = root + "#/properties/excluded"
+		items    = root + "#/properties/items"
+	)
+	r.Equal([]*Node{
+		{ID: other, GoPath: "example.com/other", Name: "Other"},
+		{ID: root, GoPath: "example.com/graph", Name: "Root"},
+		{ID: excluded, GoPath: "example.com/external", Name: "Thing", Excluded: true},
+		{ID: items, GoPath: "example.com/graph", Name: "RootItems"},
+	}, g.Nodes)
+	r.Equal([]Edge{
+		{From: root, To: other, CrossPackage: true},
+		{From: root, To: excluded, CrossPackage: true},
+		{From: root, To: items},
+		{From: items, To: other, CrossPackage: true},
+	}, g.Edges)
+
+	var buf bytes.Buffer
+	r.NoError(g.WriteDOT(&buf))
+	r.Equal(`digraph schemas {
+	node [shape=box];
+	subgraph cluster_0 {
+		label="example.com/external";
+		"`+excluded+`" [label="Thing\n`+excluded+`", style=dashed];
+	}
+	subgraph cluster_1 {
+		label="example.com/graph";
+		"`+root+`" [label="Root\n`+root+`"];
+		"`+items+`" [label="RootItems\n`+items+`"];
+	}
+	subgraph cluster_2 {
+		label="example.com/other";
+		"`+other+`" [label="Other\n`+other+`"];
+	}
+	"`+root+`" -> "`+other+`" [style=bold];
+	"`+root+`" -> "`+excluded+`" [style=bold];
+	"`+root+`" -> "`+items+`";
+	"`+items+`" -> "`+other+`" [style=bold];
+}
+`, buf.String())
+}
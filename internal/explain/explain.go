@@ -0,0 +1,239 @@
+// Package explain describes how a schema is planned: which planner generated its Go type, which planners declined it
+// and why, and how each of the type's members is rendered.
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ns1/jsonschema2go/internal/composite"
+	"github.com/ns1/jsonschema2go/internal/crawl"
+	"github.com/ns1/jsonschema2go/internal/mapobj"
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/internal/slice"
+	"github.com/ns1/jsonschema2go/internal/tuple"
+	"github.com/ns1/jsonschema2go/internal/validator"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// Explanation describes how a schema was planned
+type Explanation struct {
+	Schema   string    `json:"schema"`   // the ID of the schema
+	Location string    `json:"location"` // the location of the schema within its document
+	Planner  string    `json:"planner"`  // the name of the planner which generated the type
+	GoPath   string    `json:"goPath,omitempty"`
+	Name     string    `json:"name"`
+	Declined []Decline `json:"declined,omitempty"` // the planners tried before Planner, in order
+	Members  []Member  `json:"members,omitempty"`
+	Deps     []string  `json:"deps,omitempty"` // the IDs of the schemas which are planned as a result of this one
+}
+
+// Decline records a planner which declined the schema
+type Decline struct {
+	Planner string `json:"planner"`
+	Reason  string `json:"reason"`
+}
+
+// Member describes how a field of a struct, the items of a slice, the values of a map or an element of a tuple is
+// rendered
+type Member struct {
+	Name       string   `json:"name"` // the field name, or [] for items, [string] for values and [i] for elements
+	JSONName   string   `json:"jsonName,omitempty"`
+	Type       string   `json:"type"`
+	Pointer    bool     `json:"pointer"`
+	OmitEmpty  bool     `json:"omitEmpty"`
+	Required   bool     `json:"required"`
+	Validators []string `json:"validators,omitempty"`
+}
+
+// Explain loads the schema at the provided URL, whose fragment may be a JSON pointer to a subschema, and plans it
+// with the provided planner. If the planner is a planning.CompositePlanner, the planners it tried are reported.
+func Explain(
+	ctx context.Context,
+	planner gen.Planner,
+	loader gen.Loader,
+	typer planning.Typer,
+	u *url.URL,
+) (*Explanation, error) {
+	root, err := loader.Load(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %v: %w", u, err)
+	}
+	schema := root.Descendant(u.Fragment)
+	if schema == nil {
+		return nil, fmt.Errorf("no schema at %q in %v", u.Fragment, root)
+	}
+
+	helper := &crawl.SimpleHelper{Loader: loader, Typer: typer}
+	var (
+		pl       gen.Plan
+		name     = "planner"
+		declined []gen.Attempt
+	)
+	if c, ok := planner.(planning.CompositePlanner); ok {
+		pl, name, declined, err = c.Explain(ctx, helper, schema)
+	} else {
+		if p, ok := planner.(interface{ Name() string }); ok {
+			name = p.Name()
+		}
+		pl, err = planner.Plan(ctx, helper, schema)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Explanation{
+		Schema:   schema.String(),
+		Location: schema.Location(),
+		Planner:  name,
+		GoPath:   pl.Type().GoPath,
+		Name:     pl.Type().Name,
+		Members:  members(pl),
+	}
+	for _, a := range declined {
+		e.Declined = append(e.Declined, Decline{Planner: a.Planner, Reason: a.Message()})
+	}
+	for _, d := range helper.Deps() {
+		e.Deps = append(e.Deps, d.String())
+	}
+	return e, nil
+}
+
+func members(pl gen.Plan) (ms []Member) {
+	switch pl := pl.(type) {
+	case *composite.StructPlan:
+		for _, f := range pl.Fields {
+			name := f.Name
+			if name == "" {
+				name = "(embedded)"
+			}
+			typ := typeName(f.Type)
+			if !f.Boxed.Unknown() {
+				typ += "[" + typeName(f.Boxed) + "]"
+			}
+			ms = append(ms, Member{
+				Name:       name,
+				JSONName:   f.JSONName,
+				Type:       typ,
+				Pointer:    f.Type.Pointer,
+				OmitEmpty:  omitEmpty(f.Tag),
+				Required:   f.Required,
+				Validators: validatorNames(f.Validators()),
+			})
+		}
+	case *slice.Plan:
+		ms = append(ms, Member{
+			Name:       "[]",
+			Type:       typeName(pl.ItemType),
+			Pointer:    pl.ItemType.Pointer,
+			Validators: validatorNames(pl.ItemValidators()),
+		})
+	case *mapobj.MapPlan:
+		ms = append(ms, Member{
+			Name:       "[string]",
+			Type:       typeName(pl.ValTypeInfo),
+			Pointer:    pl.ValTypeInfo.Pointer,
+			Validators: validatorNames(validator.Sorted(pl.Validators)),
+		})
+	case *tuple.TuplePlan:
+		for i, item := range pl.Items {
+			ms = append(ms, Member{
+				Name:       "[" + strconv.Itoa(i) + "]",
+				Type:       typeName(item.Type),
+				Pointer:    item.Type.Pointer,
+				Required:   true,
+				Validators: validatorNames(item.Validators()),
+			})
+		}
+	}
+	return
+}
+
+func typeName(t gen.TypeInfo) string {
+	name := t.Name
+	if t.GoPath != "" {
+		name = t.GoPath + "." + name
+	}
+	if t.Pointer {
+		name = "*" + name
+	}
+	return name
+}
+
+func omitEmpty(tag string) bool {
+	opts := strings.Split(reflect.StructTag(strings.Trim(tag, "`")).Get("json"), ",")
+	for _, o := range opts[1:] {
+		if o == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
+
+func validatorNames(vs []validator.Validator) (names []string) {
+	for _, v := range vs {
+		names = append(names, v.Name)
+	}
+	return
+}
+
+// WriteText writes a human readable description of the explanation
+func (e *Explanation) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "schema:\t%s\n", e.Schema)
+	fmt.Fprintf(tw, "location:\t%s\n", e.Location)
+	fmt.Fprintf(tw, "planner:\t%s\n", e.Planner)
+	fmt.Fprintf(tw, "type:\t%s\n", typeName(gen.TypeInfo{GoPath: e.GoPath, Name: e.Name}))
+	if len(e.Declined) > 0 {
+		fmt.Fprintln(tw, "declined:")
+		for _, d := range e.Declined {
+			fmt.Fprintf(tw, "  %s\t%s\n", d.Planner, d.Reason)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(e.Members) > 0 {
+		fmt.Fprintln(w, "members:")
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  NAME\tJSON\tTYPE\tPOINTER\tOMITEMPTY\tREQUIRED\tVALIDATORS")
+		for _, m := range e.Members {
+			jsonName, validators := m.JSONName, strings.Join(m.Validators, ",")
+			if jsonName == "" {
+				jsonName = "-"
+			}
+			if validators == "" {
+				validators = "-"
+			}
+			fmt.Fprintf(
+				tw,
+				"  %s\t%s\t%s\t%t\t%t\t%t\t%s\n",
+				m.Name,
+				jsonName,
+				m.Type,
+				m.Pointer,
+				m.OmitEmpty,
+				m.Required,
+				validators,
+			)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(e.Deps) > 0 {
+		fmt.Fprintln(w, "deps:")
+		for _, d := range e.Deps {
+			fmt.Fprintf(w, "  %s\n", d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package explain
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	p, err := filepath.Abs("testdata/foo.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		fragment string
+		want     string
+	}{
+		{
+			name: "struct",
+			want: `schema:    https://example.com/testdata/foo.json
+location:  ` + p + `:1:1#
+planner:   object
+type:      example.com/testdata.Foo
+declined:
+  map          not a object with only additional properties
+  allOfObject  no allOf schemas
+members:
+  NAME   JSON   TYPE                          POINTER  OMITEMPTY  REQUIRED  VALIDATORS
+  Count  count  *int64                        true     true       false     -
+  Name   name   *string                       true     true       true      minLength
+  Tags   tags   example.com/testdata.FooTags  false    false      false     subschema
+deps:
+  https://example.com/testdata/foo.json#/properties/tags
+`,
+		},
+		{
+			name:     "pointer",
+			fragment: "/properties/tags",
+			want: `schema:    https://example.com/testdata/foo.json#/properties/tags
+location:  ` + p + `:7:13#/properties/tags
+planner:   slice
+type:      example.com/testdata.FooTags
+declined:
+  map          not a object with only additional properties
+  allOfObject  no allOf schemas
+  object       not an object
+  tuple        not a tuple
+members:
+  NAME  JSON  TYPE    POINTER  OMITEMPTY  REQUIRED  VALIDATORS
+  []    -     string  false    false      false     pattern
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			u, err := url.Parse("file:" + p)
+			r.NoError(err)
+			u.Fragment = tt.fragment
+
+			e, err := Explain(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, u)
+			r.NoError(err)
+
+			var buf bytes.Buffer
+			r.NoError(e.WriteText(&buf))
+			r.Equal(tt.want, buf.String())
+		})
+	}
+}
+
+func TestExplain_noSchema(t *testing.T) {
+	p, err := filepath.Abs("testdata/foo.json")
+	require.NoError(t, err)
+	u, err := url.Parse("file:" + p + "#/properties/missing")
+	require.NoError(t, err)
+
+	_, err = Explain(context.Background(), planning.Composite, gen.NewLoader(), planning.DefaultTyper, u)
+	require.EqualError(t, err, `no schema at "/properties/missing" in https://example.com/testdata/foo.json`)
+}
@@ -25,40 +25,33 @@ var (
 func (m {{ .Type.Name }}) Validate() error {
 {{ if gt .MinProperties 0 -}}
     if len(m) < {{ .MinProperties }} {
-        return &validationError{
-            errType: "min_properties",
-            message: "minimum of {{ .MinProperties }} properties",
-        }
+        return {{ $.ValidationError "min_properties" (printf "%q" (print "minimum of " .MinProperties " properties")) "" "" ` + "`" + `"/minProperties"` + "`" + ` ($.AbsoluteLocation "/minProperties") }}
     }
 {{ end -}}
 {{ if .HasMaxProperties -}}
     if len(m) > {{ .MaxProperties }} {
-        return &validationError{
-            errType: "max_properties",
-            message: "maximum of {{ .MaxProperties }} properties",
-        }
+        return {{ $.ValidationError "max_properties" (printf "%q" (print "maximum of " .MaxProperties " properties")) "" "" ` + "`" + `"/maxProperties"` + "`" + ` ($.AbsoluteLocation "/maxProperties") }}
     }
 {{ end -}}
 {{ if .MapPlan.Validators -}}
+{{ if .SortedKeys -}}
+    for _, k := range slices.Sorted(maps.Keys(m)) {
+{{ else -}}
     keys := make([]string, 0, len(m))
     for k := range m {
     	keys = append(keys, k)
     }
     for _, k := range keys {
+{{ end -}}
     	v := m[k]
     	{{ range .MapPlan.Validators }}
         {{ if eq .Name "subschema" -}}
         if err := v.Validate(); err != nil {
-            return err
+            return {{ $.ErrorPrefix }}(err, k, k, "{{ $.ValuesPointer }}")
         }
         {{ else -}}
         if {{ .Test $.NameSpace "v" }} {
-            return &validationError{
-                path: []interface{}{k},
-                jsonPath: []interface{}{k},
-                errType: "{{ .Name }}",
-                message: fmt.Sprintf({{ .Sprintf $.NameSpace "v" }}),
-            }
+            return {{ $.ValidationError .Name (print "fmt.Sprintf(" (.Sprintf $.NameSpace "v") ")") "k" "k" (.KeywordLocation $.ValuesPointer) .AbsoluteKeywordLocation }}
         }
         {{ end -}}
         {{ end -}}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"unicode"
 
 	"github.com/ns1/jsonschema2go/internal/validator"
@@ -13,8 +14,11 @@ import (
 
 //go:generate go run ../cmd/embedtmpl/embedtmpl.go mapobj map.tmpl map.gen.go
 
+// PlanMap attempts to generate a plan for an object schema with no properties which permits additional properties.
+// A schema of any other type, such as a union of types, isn't a map even if it permits additional properties, since
+// they only constrain objects.
 func PlanMap(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan, error) {
-	if schema.ChooseType() != gen.JSONObject &&
+	if schema.ChooseType() != gen.JSONObject ||
 		len(schema.Properties) != 0 ||
 		schema.AdditionalProperties == nil ||
 		(schema.AdditionalProperties.Schema == nil &&
@@ -43,17 +47,20 @@ func PlanMap(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Pl
 				return nil, fmt.Errorf("unable to submit new dependency: %w", err)
 			}
 		}
-		validators = validator.Validators(valSchema)
+		validators = validator.Validators(ctx, valSchema)
 		validator.Sorted(validators)
 	}
+	valRef := schema.AdditionalProperties.Schema != nil && schema.AdditionalProperties.Schema.IsRef()
 
 	m := &MapPlan{
 		TypeInfo:      typ,
 		ValTypeInfo:   valType,
 		ID:            schema.ID,
-		Comment:       schema.Annotations.GetString("description"),
+		Comment:       helper.Comment(schema),
 		MinProperties: schema.MinProperties,
 		Validators:    validators,
+		ValRef:        valRef,
+		SortedKeys:    gen.TargetGoVersion(ctx).Iterators(),
 	}
 	if schema.MaxProperties != nil {
 		m.HasMaxProperties = true
@@ -71,6 +78,8 @@ type MapPlan struct {
 	HasMaxProperties bool
 	MaxProperties    uint64
 	Validators       []validator.Validator
+	ValRef           bool // whether the schema of the values is a $ref
+	SortedKeys       bool // whether the values are validated in the order of their sorted keys, which requires Go 1.23
 	Comment          string
 }
 
@@ -80,6 +89,9 @@ func (m *MapPlan) Type() gen.TypeInfo {
 
 func (m *MapPlan) Deps() []gen.TypeInfo {
 	deps := []gen.TypeInfo{m.ValTypeInfo}
+	if m.SortedKeys {
+		deps = append(deps, gen.TypeInfo{GoPath: "maps", Name: "Keys"}, gen.TypeInfo{GoPath: "slices", Name: "Sorted"})
+	}
 	for _, v := range m.Validators {
 		deps = append(deps, v.Deps...)
 	}
@@ -110,6 +122,23 @@ func (m *mapPlanContext) ValidateInitialize() bool {
 	return false
 }
 
+// ValuesPointer returns a JSON pointer to the schema of the values relative to the map's schema, following any $ref
+func (m *mapPlanContext) ValuesPointer() string {
+	if m.ValRef {
+		return "/additionalProperties/$ref"
+	}
+	return "/additionalProperties"
+}
+
+// AbsoluteLocation returns the quoted absolute URI of the JSON pointer relative to this map's schema, or an empty
+// string if unknown
+func (m *mapPlanContext) AbsoluteLocation(pointer string) string {
+	if m.ID == nil {
+		return ""
+	}
+	return strconv.Quote(validator.AbsoluteLocation(m.ID, pointer))
+}
+
 func (m *mapPlanContext) NameSpace() string {
 	s := []rune(m.TypeInfo.Name)
 	if len(s) > 0 {
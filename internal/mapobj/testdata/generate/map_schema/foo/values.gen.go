@@ -2,19 +2,28 @@
 package foo
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
 // Bar is generated from https://example.com/testdata/generate/map_schema/foo/bar.json
 // Bar contains some info
 type Bar struct {
-	Baz BarBaz `json:"baz,omitempty"`
-	Biz BarBiz `json:"biz,omitempty"`
+	Baz BarBaz  `json:"baz,omitempty"`
+	Biz BarBiz  `json:"biz,omitempty"`
+	Buz *BarBuz `json:"buz,omitempty"`
 }
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/map_schema/foo/bar.json
 func (m *Bar) Validate() error {
+	if m.Buz != nil {
+		if err := m.Buz.Validate(); err != nil {
+			return jsvalidate.Prefix(err, "Buz", "buz", "/properties/buz")
+		}
+	}
 	return nil
 }
 
@@ -28,6 +37,100 @@ func (m *BarBizAdditionalProperties) Validate() error {
 	return nil
 }
 
+// BarBuz is generated from https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/buz
+// only objects are maps, however other types may permit additional properties
+type BarBuz struct {
+	Value interface{}
+}
+
+// Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/buz
+func (m *BarBuz) Validate() error {
+	switch m.Value.(type) {
+	case string, int64:
+	default:
+		return &jsvalidate.ValidationError{
+			ErrType:                 "type",
+			Message:                 fmt.Sprintf("unsupported type: %T", m.Value),
+			KeywordLocation:         "/type",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/buz/type",
+		}
+	}
+	return nil
+}
+
+func (m *BarBuz) UnmarshalJSON(data []byte) error {
+	tok, err := json.NewDecoder(bytes.NewReader(data)).Token()
+	if err != nil {
+		return err
+	}
+	switch tok.(type) {
+	case string:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		m.Value = s
+		return nil
+	case float64:
+		var i int64
+		if err := json.Unmarshal(data, &i); err != nil {
+			return err
+		}
+		m.Value = i
+		return nil
+	}
+	return fmt.Errorf("unsupported type: %T", tok)
+}
+
+func (m *BarBuz) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Value)
+}
+
+// BarBuzKind identifies the type of value held by a BarBuz
+type BarBuzKind int
+
+const (
+	// BarBuzKindNull is the kind of a BarBuz holding null, or no value
+	BarBuzKindNull BarBuzKind = iota
+	// BarBuzKindString is the kind of a BarBuz holding a value of type string
+	BarBuzKindString
+	// BarBuzKindInteger is the kind of a BarBuz holding a value of type int64
+	BarBuzKindInteger
+)
+
+// Kind returns the type of value held
+func (m *BarBuz) Kind() BarBuzKind {
+	switch m.Value.(type) {
+	case string:
+		return BarBuzKindString
+	case int64:
+		return BarBuzKindInteger
+	}
+	return BarBuzKindNull
+}
+
+// AsString returns the value and true if it is of type string
+func (m *BarBuz) AsString() (string, bool) {
+	v, ok := m.Value.(string)
+	return v, ok
+}
+
+// BarBuzFromString returns a BarBuz holding the provided value
+func BarBuzFromString(v string) *BarBuz {
+	return &BarBuz{Value: v}
+}
+
+// AsInteger returns the value and true if it is of type int64
+func (m *BarBuz) AsInteger() (int64, bool) {
+	v, ok := m.Value.(int64)
+	return v, ok
+}
+
+// BarBuzFromInteger returns a BarBuz holding the provided value
+func BarBuzFromInteger(v int64) *BarBuz {
+	return &BarBuz{Value: v}
+}
+
 // BarBaz is generated from https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/baz
 type BarBaz map[string]string
 
@@ -38,15 +141,19 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/baz
 func (m BarBaz) Validate() error {
 	if len(m) < 3 {
-		return &validationError{
-			errType: "min_properties",
-			message: "minimum of 3 properties",
+		return &jsvalidate.ValidationError{
+			ErrType:                 "min_properties",
+			Message:                 "minimum of 3 properties",
+			KeywordLocation:         "/minProperties",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/baz/minProperties",
 		}
 	}
 	if len(m) > 10 {
-		return &validationError{
-			errType: "max_properties",
-			message: "maximum of 10 properties",
+		return &jsvalidate.ValidationError{
+			ErrType:                 "max_properties",
+			Message:                 "maximum of 10 properties",
+			KeywordLocation:         "/maxProperties",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/baz/maxProperties",
 		}
 	}
 	keys := make([]string, 0, len(m))
@@ -57,11 +164,13 @@ func (m BarBaz) Validate() error {
 		v := m[k]
 
 		if !barBazPattern.MatchString(v) {
-			return &validationError{
-				path:     []interface{}{k},
-				jsonPath: []interface{}{k},
-				errType:  "pattern",
-				message:  fmt.Sprintf(`must match '^abc' but got %q`, v),
+			return &jsvalidate.ValidationError{
+				ErrType:                 "pattern",
+				Path:                    []interface{}{k},
+				JSONPath:                []interface{}{k},
+				Message:                 fmt.Sprintf(`must match '^abc' but got %q`, v),
+				KeywordLocation:         "/additionalProperties/pattern",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/map_schema/foo/bar.json#/properties/baz/additionalProperties/pattern",
 			}
 		}
 	}
@@ -81,42 +190,8 @@ func (m BarBiz) Validate() error {
 		v := m[k]
 
 		if err := v.Validate(); err != nil {
-			return err
+			return jsvalidate.Prefix(err, k, k, "/additionalProperties")
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
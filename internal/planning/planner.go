@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"sort"
 	"strconv"
@@ -26,42 +25,59 @@ var (
 		plannerFunc("tuple", tuple.PlanTuple),
 		plannerFunc("slice", slice.Build),
 		plannerFunc("discriminatedOneOf", composite.PlanDiscriminatedOneOfObject),
+		plannerFunc("oneOfObjects", composite.PlanOneOfObjects),
 		plannerFunc("oneOfDiffTypes", composite.PlanOneOfDiffTypes),
+		plannerFunc("typeUnion", composite.PlanTypeUnion),
 	}
 )
 
 type CompositePlanner []gen.Planner
 
+// Plan tries each planner in turn, returning the first Plan generated. If every planner declines, a *gen.PlanError
+// records why.
 func (c CompositePlanner) Plan(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan, error) {
+	pl, _, _, err := c.Explain(ctx, helper, schema)
+	return pl, err
+}
+
+// Explain plans the schema as Plan does, additionally returning the name of the planner which generated the Plan and
+// the planners which declined the schema before it.
+func (c CompositePlanner) Explain(
+	ctx context.Context,
+	helper gen.Helper,
+	schema *gen.Schema,
+) (pl gen.Plan, planner string, declined []gen.Attempt, err error) {
 	for i, p := range c {
 		name := strconv.Itoa(i)
 		if p, ok := p.(interface{ Name() string }); ok {
 			name = p.Name()
 		}
-		if gen.IsDebug(ctx) {
-			log.Printf("checking planner %v for %v", name, schema)
-		}
-		pl, err := p.Plan(ctx, helper, schema)
+		gen.Log(ctx).Debug("checking planner", "planner", name, "schema", schema)
+		pl, err = p.Plan(ctx, helper, schema)
 		switch {
 		case errors.Is(err, gen.ErrContinue),
 			errors.Is(err, ErrUnknownType),
 			helper.ErrSimpleTypeUnknown(err):
-			if gen.IsDebug(ctx) {
-				log.Printf("planner %v for %v: %v", name, schema, err)
-			}
+			gen.Log(ctx).Debug("planner declined", "planner", name, "schema", schema, "reason", err)
+			declined = append(declined, gen.Attempt{Planner: name, Reason: err})
 		case err != nil:
-			return nil, err
+			return nil, name, declined, err
 		default:
 			if pl == nil {
 				panic(fmt.Errorf("planner %v returned a nil plan for %v", name, schema))
 			}
-			if gen.IsDebug(ctx) {
-				log.Printf("planner %v: planned %v %v", name, pl.Type().GoPath, pl.Type().Name)
-			}
-			return pl, nil
+			gen.Log(ctx).Debug(
+				"planned type",
+				"planner", name,
+				"schema", schema,
+				"gopath", pl.Type().GoPath,
+				"type", pl.Type().Name,
+			)
+			return pl, name, declined, nil
 		}
 	}
-	return nil, fmt.Errorf("unable to plan %v", schema)
+	err = &gen.PlanError{Diagnostic: schema.Diagnostic("unable to plan %v", schema), Attempts: declined}
+	return nil, "", declined, err
 }
 
 func plannerFunc(
@@ -84,77 +100,29 @@ func (p namedPlannerFunc) Plan(ctx context.Context, helper gen.Helper, schema *g
 	return p.f(ctx, helper, schema)
 }
 
-func NewNamer(knownInitialisms []string) *Namer {
-	m := make(map[string]bool)
-	for _, n := range knownInitialisms {
-		m[n] = true
-	}
-	return &Namer{m}
-}
+// CommonInitialisms are the initialisms which golint expects to be consistently cased, such as URL and JSON
+var CommonInitialisms = gen.CommonInitialisms
 
-type Namer struct {
-	knownInitialisms map[string]bool
+// NewNamer returns a namer which upper cases the provided initialisms, in any case, when they appear as words
+func NewNamer(knownInitialisms []string) *Namer {
+	return gen.NewNamer(knownInitialisms)
 }
 
-func (n *Namer) JSONPropertyExported(name string) string {
-	if strings.ToUpper(name) == name {
-		return n.exportedIdentifier([][]rune{[]rune(strings.ToLower(name))})
-	}
-
-	var (
-		current []rune
-		parts   [][]rune
-	)
-	// split words
-	for _, r := range []rune(name) {
-		if r == '-' || r == '_' || unicode.IsSpace(r) {
-			// exclusive word boundary
-			if len(current) != 0 {
-				parts = append(parts, current)
-				current = nil
-			}
-			continue
-		}
-		if unicode.IsUpper(r) {
-			// inclusive word boundary
-			if len(current) != 0 {
-				parts = append(parts, current)
-			}
-			current = []rune{unicode.ToLower(r)}
-			continue
-		}
-
-		current = append(current, r)
-	}
-
-	if len(current) > 0 {
-		parts = append(parts, current)
-	}
+// Namer converts JSON names into Go identifiers
+type Namer = gen.Namer
 
-	return n.exportedIdentifier(parts)
+var DefaultTyper = Typer{
+	Namer:    NewNamer(CommonInitialisms),
+	TypeFunc: MakeTypeFromID(nil),
+	Primitives: map[gen.JSONType]string{
+		gen.JSONBoolean: "bool",
+		gen.JSONInteger: "int64",
+		gen.JSONNumber:  "float64",
+		gen.JSONNull:    "interface{}",
+		gen.JSONString:  "string",
+	},
 }
 
-func (n *Namer) exportedIdentifier(parts [][]rune) string {
-	var words []string
-	for _, rs := range parts {
-		if word := string(rs); n.knownInitialisms[word] {
-			words = append(words, strings.ToUpper(word))
-			continue
-		}
-		rs[0] = unicode.ToUpper(rs[0])
-		words = append(words, string(rs))
-	}
-	return strings.Join(words, "")
-}
-
-var DefaultTyper = Typer{NewNamer([]string{"id", "http"}), MakeTypeFromID(nil), map[gen.JSONType]string{
-	gen.JSONBoolean: "bool",
-	gen.JSONInteger: "int64",
-	gen.JSONNumber:  "float64",
-	gen.JSONNull:    "interface{}",
-	gen.JSONString:  "string",
-}}
-
 func DefaultTypeFunc(s *gen.Schema) gen.TypeInfo {
 	parts := strings.SplitN(s.Config.GoPath, "#", 2)
 	if len(parts) == 2 {
@@ -181,6 +149,9 @@ type Typer struct {
 	*Namer
 	TypeFunc   func(s *gen.Schema) gen.TypeInfo
 	Primitives map[gen.JSONType]string
+	Titles     bool              // whether to name types after the titles of their schemas
+	Names      map[string]string // type names by schema ID, overriding any other name
+	Pointers   gen.PointerPolicy // the policy for primitive struct fields unless overridden by a schema
 }
 
 func (d Typer) typeInfo(s *gen.Schema) gen.TypeInfo {
@@ -201,9 +172,23 @@ func (d Typer) TypeInfo(s *gen.Schema) (gen.TypeInfo, error) {
 	return t, nil
 }
 
+// TypeInfoHinted returns the type info for a schema of the provided JSON type. The Go path of a named type comes from
+// the TypeFunc; its name is, in order of precedence, from Names, the schema's x-jsonschema2go typeName, its
+// x-jsonschema2go gopath, its title if Titles is set, or else the TypeFunc. Only the last three pass through the Namer.
 func (d Typer) TypeInfoHinted(s *gen.Schema, t gen.JSONType) gen.TypeInfo {
 	if t == gen.JSONUnknown || t == gen.JSONArray || t == gen.JSONObject {
 		if f := d.TypeFunc(s); f.Name != "" {
+			if name, ok := d.Names[s.String()]; ok {
+				f.Name = name
+				return f
+			}
+			if s.Config.TypeName != "" {
+				f.Name = s.Config.TypeName
+				return f
+			}
+			if title := s.Annotations.GetString("title"); d.Titles && title != "" && s.Config.GoPath == "" {
+				f.Name = title
+			}
 			f.Name = d.Namer.JSONPropertyExported(f.Name)
 			return f
 		}
@@ -211,6 +196,31 @@ func (d Typer) TypeInfoHinted(s *gen.Schema, t gen.JSONType) gen.TypeInfo {
 	return gen.TypeInfo{Name: d.Primitive(t)}
 }
 
+// Comment returns the doc comment for the type or field generated from a schema: its description, preceded by its
+// title if Titles is set.
+func (d Typer) Comment(s *gen.Schema) string {
+	description := s.Annotations.GetString("description")
+	title := s.Annotations.GetString("title")
+	switch {
+	case !d.Titles || title == "":
+		return description
+	case description == "":
+		return title
+	}
+	return title + "\n\n" + description
+}
+
+// PointerPolicy returns the policy for a primitive field of a struct: that of the field's schema, else that of the
+// struct's schema, else Pointers, else gen.PointersAlways.
+func (d Typer) PointerPolicy(parent, field *gen.Schema) gen.PointerPolicy {
+	for _, p := range []gen.PointerPolicy{field.Config.Pointers, parent.Config.Pointers, d.Pointers} {
+		if p != "" {
+			return p
+		}
+	}
+	return gen.PointersAlways
+}
+
 func (d Typer) Primitive(s gen.JSONType) string {
 	return d.Primitives[s]
 }
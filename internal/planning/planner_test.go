@@ -1,9 +1,74 @@
 package planning
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/ns1/jsonschema2go/pkg/gen"
+	"github.com/stretchr/testify/require"
 )
 
+type stubHelper struct {
+	gen.Helper
+}
+
+func (stubHelper) ErrSimpleTypeUnknown(err error) bool {
+	return false
+}
+
+func TestCompositePlanner_PlanError(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "planning")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "foo.json")
+	r.NoError(ioutil.WriteFile(fname, []byte(`{
+  "id": "https://example.com/foo.json",
+  "properties": {
+    "bar": {"type": "string"}
+  }
+}`), 0644))
+	u, err := url.Parse("file:" + fname)
+	r.NoError(err)
+
+	loader := gen.NewLoader()
+	root, err := loader.Load(context.Background(), u)
+	r.NoError(err)
+	schema, err := root.Properties["bar"].Resolve(context.Background(), root, loader)
+	r.NoError(err)
+	r.Equal("/properties/bar", schema.Pointer)
+	r.Equal(gen.Position{Line: 4, Column: 12}, schema.Pos)
+
+	planner := CompositePlanner{
+		plannerFunc("first", func(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan, error) {
+			return nil, fmt.Errorf("not an object: %w", gen.ErrContinue)
+		}),
+		plannerFunc("second", func(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan, error) {
+			return nil, fmt.Errorf("no oneOf schemas: %w", gen.ErrContinue)
+		}),
+	}
+	_, err = planner.Plan(context.Background(), stubHelper{}, schema)
+
+	var planErr *gen.PlanError
+	r.True(errors.As(err, &planErr), "expected a plan error but got %v", err)
+	r.Len(planErr.Attempts, 2)
+	r.Equal(
+		fname+":4:12#/properties/bar: unable to plan https://example.com/foo.json#/properties/bar\n"+
+			"\tfirst: not an object\n"+
+			"\tsecond: no oneOf schemas",
+		err.Error(),
+	)
+}
+
 func Test_jsonPropertyToExportedName(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -40,6 +105,51 @@ func Test_jsonPropertyToExportedName(t *testing.T) {
 			input: "HTTP",
 			want:  "HTTP",
 		},
+		{
+			name:  "common initialisms",
+			input: "api_url_json",
+			want:  "APIURLJSON",
+		},
+		{
+			name:  "all upper snake case",
+			input: "HTTP_PROXY",
+			want:  "HTTPProxy",
+		},
+		{
+			name:  "punctuation",
+			input: "$ref",
+			want:  "Ref",
+		},
+		{
+			name:  "dotted",
+			input: "a.b@c",
+			want:  "ABC",
+		},
+		{
+			name:  "leading digit",
+			input: "2fa",
+			want:  "X2fa",
+		},
+		{
+			name:  "keyword",
+			input: "type",
+			want:  "Type",
+		},
+		{
+			name:  "cased unicode",
+			input: "émoji",
+			want:  "Émoji",
+		},
+		{
+			name:  "uncased unicode",
+			input: "名前",
+			want:  "X名前",
+		},
+		{
+			name:  "no letters or digits",
+			input: "$",
+			want:  "X",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,6 +160,16 @@ func Test_jsonPropertyToExportedName(t *testing.T) {
 	}
 }
 
+func TestNamer_With(t *testing.T) {
+	namer := DefaultTyper.Namer.With("SKU")
+	if got, want := namer.JSONPropertyExported("sku_url"), "SKUURL"; got != want {
+		t.Errorf("JSONPropertyExported() = %v, want %v", got, want)
+	}
+	if got, want := DefaultTyper.JSONPropertyExported("sku_url"), "SkuURL"; got != want {
+		t.Errorf("default JSONPropertyExported() = %v, want %v", got, want)
+	}
+}
+
 func Test_typeFromID(t *testing.T) {
 	for _, tt := range []struct {
 		name                   string
@@ -150,3 +270,90 @@ func Test_mapPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestTyper_titles(t *testing.T) {
+	schema := func(raw string) *gen.Schema {
+		var s gen.Schema
+		require.NoError(t, json.Unmarshal([]byte(raw), &s))
+		return &s
+	}
+	typer := DefaultTyper
+	typer.TypeFunc = MakeTypeFromID([][2]string{{"https://example.com/", "example.com/"}})
+	titled := typer
+	titled.Titles = true
+
+	tests := []struct {
+		name        string
+		typer       Typer
+		schema      *gen.Schema
+		wantType    gen.TypeInfo
+		wantComment string
+	}{
+		{
+			name:        "titles disabled",
+			typer:       typer,
+			schema:      schema(`{"id": "https://example.com/foo/bar.json", "title": "widget settings", "description": "hi"}`),
+			wantType:    gen.TypeInfo{GoPath: "example.com/foo", Name: "Bar"},
+			wantComment: "hi",
+		},
+		{
+			name:        "title",
+			typer:       titled,
+			schema:      schema(`{"id": "https://example.com/foo/bar.json", "title": "widget settings", "description": "hi"}`),
+			wantType:    gen.TypeInfo{GoPath: "example.com/foo", Name: "WidgetSettings"},
+			wantComment: "widget settings\n\nhi",
+		},
+		{
+			name:        "title only",
+			typer:       titled,
+			schema:      schema(`{"id": "https://example.com/foo/bar.json", "title": "widget settings"}`),
+			wantType:    gen.TypeInfo{GoPath: "example.com/foo", Name: "WidgetSettings"},
+			wantComment: "widget settings",
+		},
+		{
+			name:  "gopath takes precedence",
+			typer: titled,
+			schema: schema(`{
+				"id": "https://example.com/foo/bar.json",
+				"title": "widget settings",
+				"x-jsonschema2go": {"gopath": "example.com/baz#Baz"}
+			}`),
+			wantType:    gen.TypeInfo{GoPath: "example.com/baz", Name: "Baz"},
+			wantComment: "widget settings",
+		},
+		{
+			name: "names take precedence",
+			typer: func() Typer {
+				t := titled
+				t.Names = map[string]string{"https://example.com/foo/bar.json": "Bar2"}
+				return t
+			}(),
+			schema:      schema(`{"id": "https://example.com/foo/bar.json", "title": "widget settings"}`),
+			wantType:    gen.TypeInfo{GoPath: "example.com/foo", Name: "Bar2"},
+			wantComment: "widget settings",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantType, tt.typer.TypeInfoHinted(tt.schema, gen.JSONObject))
+			require.Equal(t, tt.wantComment, tt.typer.Comment(tt.schema))
+		})
+	}
+}
+
+func TestTyper_PointerPolicy(t *testing.T) {
+	withPolicy := func(p gen.PointerPolicy) *gen.Schema {
+		return &gen.Schema{Config: gen.Config{Pointers: p}}
+	}
+	typer := DefaultTyper
+	require.Equal(t, gen.PointersAlways, typer.PointerPolicy(withPolicy(""), withPolicy("")))
+
+	typer.Pointers = gen.PointersOptional
+	require.Equal(t, gen.PointersOptional, typer.PointerPolicy(withPolicy(""), withPolicy("")))
+	require.Equal(t, gen.PointersGeneric, typer.PointerPolicy(withPolicy(gen.PointersGeneric), withPolicy("")))
+	require.Equal(
+		t,
+		gen.PointersAlways,
+		typer.PointerPolicy(withPolicy(gen.PointersGeneric), withPolicy(gen.PointersAlways)),
+	)
+}
@@ -1,21 +1,101 @@
 package print
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"github.com/ns1/jsonschema2go/internal/composite"
+	"github.com/ns1/jsonschema2go/internal/mapobj"
 	"github.com/ns1/jsonschema2go/internal/planning"
+	"github.com/ns1/jsonschema2go/internal/slice"
+	"github.com/ns1/jsonschema2go/internal/tuple"
 	"github.com/ns1/jsonschema2go/pkg/gen"
-	"log"
+	"io"
+	"io/ioutil"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 )
 
+// DefaultFileName is the name of the single file generated per Go package when no other pattern is configured.
+const DefaultFileName = "values.gen.go"
+
+// Config controls how the plans of each Go package are laid out into files.
+type Config struct {
+	// FileName is the pattern used to name generated files. A `*` in the pattern is replaced with the base name of
+	// the file: the name of the top level schema when splitting, or "values" otherwise.
+	FileName string
+	// Split generates one file per top level schema rather than a single file per Go package.
+	Split bool
+	// SelfContained renders unexported validation error types into each generated package rather than using those of
+	// the shared runtime package.
+	SelfContained bool
+	// Clean removes previously generated files which are no longer produced from the directory of each package printed.
+	// Only files beginning with the jsonschema2go generated code header are considered. Other directories, which may
+	// hold packages generated by other invocations, are left alone. As a result, the files of a package whose schemas
+	// have all been removed are never cleaned, since the package is no longer printed; they must be removed by hand.
+	Clean bool
+}
+
+// generatedHeader is the first line of every file rendered by the default template, and identifies files which are
+// safe to remove when cleaning.
+const generatedHeader = "// Code generated by jsonschema2go. DO NOT EDIT."
+
+func (c Config) fileName(base string) (string, error) {
+	pattern := c.FileName
+	switch {
+	case pattern == "" && c.Split:
+		pattern = "*.gen.go"
+	case pattern == "":
+		pattern = DefaultFileName
+	case c.Split && strings.Count(pattern, "*") != 1:
+		return "", fmt.Errorf("file name pattern %q must contain exactly one * when splitting files", pattern)
+	}
+	name := strings.Replace(pattern, "*", base, 1)
+	if name != filepath.Base(name) {
+		return "", fmt.Errorf("file name %q must not contain a directory", name)
+	}
+	return name, nil
+}
+
+// Files groups the plans for a single Go package by the name of the file they should be rendered to. The returned
+// helpers value is the name of the file which should contain the package's own validation error types when
+// self-contained.
+func (c Config) Files(plans []gen.Plan) (files map[string][]gen.Plan, helpers string, _ error) {
+	files = make(map[string][]gen.Plan)
+	for _, p := range plans {
+		base := "values"
+		if c.Split {
+			base = fileBase(p)
+		}
+		name, err := c.fileName(base)
+		if err != nil {
+			return nil, "", err
+		}
+		files[name] = append(files[name], p)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		helpers = names[0]
+	}
+	return files, helpers, nil
+}
+
 func Print(
 	ctx context.Context,
 	printer Printer,
 	grouped map[string][]gen.Plan,
 	prefixes [][2]string,
+	conf Config,
 ) error {
 	var childRoutines sync.WaitGroup
 	defer childRoutines.Wait()
@@ -35,26 +115,33 @@ func Print(
 				if path == "" {
 					return fmt.Errorf("unable to map go path: %q", k)
 				}
+				files, helpers, err := conf.Files(group)
+				if err != nil {
+					return fmt.Errorf("unable to lay out files for %v: %w", k, err)
+				}
 				if err := os.MkdirAll(path, 0755); err != nil {
 					return fmt.Errorf("unable to create dir %q: %w", path, err)
 				}
 
-				p := filepath.Join(path, "values.gen.go")
-
-				f, err := os.Create(p)
-				if err != nil {
-					return fmt.Errorf("unable to open: %w", err)
-				}
-				defer f.Close()
-
-				if err := printer.Print(ctx, f, k, group); err != nil {
-					return fmt.Errorf("unable to print %v %v: %w", p, k, err)
+				for name, plans := range files {
+					p := filepath.Join(path, name)
+					errs := SharedErrors
+					switch {
+					case conf.SelfContained && name == helpers:
+						errs = LocalErrors
+					case conf.SelfContained:
+						errs = LocalErrorsElsewhere
+					}
+					if err := printFile(ctx, printer, p, k, plans, errs); err != nil {
+						return err
+					}
+					gen.Log(ctx).Info("printed file", "file", p, "gopath", k, "plans", len(plans))
 				}
-				if gen.IsDebug(ctx) {
-					log.Printf("printer: successfully printed %d plans to %v", len(group), p)
+				if conf.Clean {
+					return removeStale(ctx, path, files)
 				}
 				return nil
-			}();
+			}()
 			select {
 			case errs <- err:
 			case <-ctx.Done():
@@ -74,3 +161,89 @@ func Print(
 	}
 	return nil
 }
+
+func printFile(ctx context.Context, printer Printer, p, goPath string, plans []gen.Plan, errs Errors) error {
+	var buf bytes.Buffer
+	if err := printer.Print(ctx, &buf, goPath, plans, errs); err != nil {
+		// the existing file is left in place; any partial output is only of use for debugging
+		if buf.Len() > 0 {
+			gen.Log(ctx).Debug("partial output", "file", p, "gopath", goPath, "output", buf.String())
+		}
+		return fmt.Errorf("unable to print %v %v: %w", p, goPath, err)
+	}
+
+	// leave identical files untouched so their modification times don't trigger rebuilds
+	if existing, err := ioutil.ReadFile(p); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		gen.Log(ctx).Debug("file is unchanged", "file", p, "gopath", goPath)
+		return nil
+	}
+	if err := ioutil.WriteFile(p, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("unable to write: %w", err)
+	}
+	return nil
+}
+
+// removeStale deletes generated Go files in dir which are not among the provided files
+func removeStale(ctx context.Context, dir string, files map[string][]gen.Plan) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("unable to list dir %q: %w", dir, err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".go" {
+			continue
+		}
+		if _, ok := files[e.Name()]; ok {
+			continue
+		}
+		p := filepath.Join(dir, e.Name())
+		generated, err := isGenerated(p)
+		if err != nil {
+			return err
+		}
+		if !generated {
+			continue
+		}
+		if err := os.Remove(p); err != nil {
+			return fmt.Errorf("unable to remove stale file %q: %w", p, err)
+		}
+		gen.Log(ctx).Info("removed stale file", "file", p)
+	}
+	return nil
+}
+
+func isGenerated(p string) (bool, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return false, fmt.Errorf("unable to open %q: %w", p, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, len(generatedHeader))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false, nil // too short to be generated
+	}
+	return string(header) == generatedHeader, nil
+}
+
+// fileBase derives the base file name for a plan from the top level schema it was generated from, falling back to
+// its type name
+func fileBase(plan gen.Plan) string {
+	var id *url.URL
+	switch p := plan.(type) {
+	case *composite.StructPlan:
+		id = p.ID
+	case *slice.Plan:
+		id = p.ID
+	case *mapobj.MapPlan:
+		id = p.ID
+	case *tuple.TuplePlan:
+		id, _ = url.Parse(p.ID())
+	}
+	if id != nil {
+		if name := strings.SplitN(path.Base(id.Path), ".", 2)[0]; name != "" && name != "/" {
+			return strings.ToLower(name)
+		}
+	}
+	return strings.ToLower(plan.Type().Name)
+}
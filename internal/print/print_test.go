@@ -0,0 +1,239 @@
+package print
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ns1/jsonschema2go/internal/composite"
+	"github.com/ns1/jsonschema2go/internal/slice"
+	"github.com/ns1/jsonschema2go/pkg/gen"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func TestConfig_Files(t *testing.T) {
+	var (
+		bar    = &composite.StructPlan{TypeInfo: gen.TypeInfo{Name: "Bar"}, ID: mustParse("https://example.com/foo/bar.json")}
+		barBaz = &slice.Plan{TypeInfo: gen.TypeInfo{Name: "BarBaz"}, ID: mustParse("https://example.com/foo/bar.json#/properties/baz")}
+		qux    = &composite.StructPlan{TypeInfo: gen.TypeInfo{Name: "Qux"}, ID: mustParse("https://example.com/foo/Qux.json")}
+		noID   = &composite.StructPlan{TypeInfo: gen.TypeInfo{Name: "Anonymous"}}
+	)
+	plans := []gen.Plan{bar, barBaz, qux, noID}
+
+	tests := []struct {
+		name        string
+		conf        Config
+		wantFiles   map[string][]gen.Plan
+		wantHelpers string
+		wantErr     bool
+	}{
+		{
+			name:        "default",
+			wantFiles:   map[string][]gen.Plan{"values.gen.go": plans},
+			wantHelpers: "values.gen.go",
+		},
+		{
+			name:        "custom single",
+			conf:        Config{FileName: "*_types.gen.go"},
+			wantFiles:   map[string][]gen.Plan{"values_types.gen.go": plans},
+			wantHelpers: "values_types.gen.go",
+		},
+		{
+			name: "split",
+			conf: Config{Split: true},
+			wantFiles: map[string][]gen.Plan{
+				"anonymous.gen.go": {noID},
+				"bar.gen.go":       {bar, barBaz},
+				"qux.gen.go":       {qux},
+			},
+			wantHelpers: "anonymous.gen.go",
+		},
+		{
+			name: "split with pattern",
+			conf: Config{FileName: "schema_*.go", Split: true},
+			wantFiles: map[string][]gen.Plan{
+				"schema_anonymous.go": {noID},
+				"schema_bar.go":       {bar, barBaz},
+				"schema_qux.go":       {qux},
+			},
+			wantHelpers: "schema_anonymous.go",
+		},
+		{
+			name:    "split without wildcard",
+			conf:    Config{FileName: "values.gen.go", Split: true},
+			wantErr: true,
+		},
+		{
+			name:    "directory",
+			conf:    Config{FileName: "foo/*.gen.go"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			files, helpers, err := tt.conf.Files(plans)
+			if tt.wantErr {
+				r.Error(err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.wantFiles, files)
+			r.Equal(tt.wantHelpers, helpers)
+		})
+	}
+}
+
+func TestPrint_Split(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "split")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	grouped := map[string][]gen.Plan{
+		"example.com/foo": {
+			&composite.StructPlan{
+				TypeInfo: gen.TypeInfo{GoPath: "example.com/foo", Name: "Bar"},
+				ID:       mustParse("https://example.com/foo/bar.json"),
+				Fields: []composite.StructField{
+					{Name: "Count", Type: gen.TypeInfo{Name: "int"}, Tag: tag(`json:"count,omitempty"`)},
+				},
+			},
+			&slice.Plan{
+				TypeInfo: gen.TypeInfo{GoPath: "example.com/foo", Name: "Baz"},
+				ID:       mustParse("https://example.com/foo/baz.json"),
+				ItemType: gen.TypeInfo{Name: "string"},
+			},
+		},
+	}
+
+	r.NoError(Print(
+		context.Background(),
+		New(nil),
+		grouped,
+		[][2]string{{"example.com/foo", dir}},
+		Config{Split: true, SelfContained: true},
+	))
+
+	entries, err := ioutil.ReadDir(dir)
+	r.NoError(err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	r.Equal([]string{"bar.gen.go", "baz.gen.go"}, names)
+
+	bar, err := ioutil.ReadFile(filepath.Join(dir, "bar.gen.go"))
+	r.NoError(err)
+	r.Contains(string(bar), "type Bar struct")
+	r.Contains(string(bar), "type validationError struct")
+	r.Contains(string(bar), `"fmt"`)
+
+	baz, err := ioutil.ReadFile(filepath.Join(dir, "baz.gen.go"))
+	r.NoError(err)
+	r.Contains(string(baz), "type Baz []string")
+	r.NotContains(string(baz), "validationError")
+	r.False(strings.Contains(string(baz), `"fmt"`), "unused import should be pruned:\n%s", baz)
+}
+
+func TestPrint_Rewrite(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "rewrite")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	plan := func(name string) gen.Plan {
+		return &composite.StructPlan{
+			TypeInfo: gen.TypeInfo{GoPath: "example.com/foo", Name: name},
+			ID:       mustParse("https://example.com/foo/" + strings.ToLower(name) + ".json"),
+		}
+	}
+	prefixes := [][2]string{{"example.com/foo", dir}}
+	conf := Config{Split: true, Clean: true}
+
+	r.NoError(Print(context.Background(), New(nil), map[string][]gen.Plan{
+		"example.com/foo": {plan("Bar"), plan("Baz")},
+	}, prefixes, conf))
+
+	// a hand written file which must survive cleaning
+	handWritten := filepath.Join(dir, "baz.go")
+	r.NoError(ioutil.WriteFile(handWritten, []byte("package foo\n"), 0644))
+
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	barPath := filepath.Join(dir, "bar.gen.go")
+	r.NoError(os.Chtimes(barPath, old, old))
+
+	r.NoError(Print(context.Background(), New(nil), map[string][]gen.Plan{
+		"example.com/foo": {plan("Bar")},
+	}, prefixes, conf))
+
+	info, err := os.Stat(barPath)
+	r.NoError(err)
+	r.True(info.ModTime().Equal(old), "unchanged file should not be rewritten")
+
+	_, err = os.Stat(filepath.Join(dir, "baz.gen.go"))
+	r.True(os.IsNotExist(err), "stale generated file should be removed")
+
+	_, err = os.Stat(handWritten)
+	r.NoError(err)
+
+	// a package which this run doesn't print, such as one generated by another invocation under the same prefix
+	otherPkg := filepath.Join(dir, "qux")
+	r.NoError(os.MkdirAll(otherPkg, 0755))
+	otherFile := filepath.Join(otherPkg, "values.gen.go")
+	r.NoError(ioutil.WriteFile(otherFile, []byte(generatedHeader+"\n\npackage qux\n"), 0644))
+
+	r.NoError(Print(context.Background(), New(nil), map[string][]gen.Plan{
+		"example.com/foo": {plan("Bar")},
+	}, prefixes, conf))
+
+	_, err = os.Stat(otherFile)
+	r.NoError(err, "generated file of a package which wasn't printed should be left alone")
+}
+
+type failingPrinter struct{}
+
+func (failingPrinter) Print(ctx context.Context, w io.Writer, goPath string, plans []gen.Plan, errs Errors) error {
+	_, _ = w.Write([]byte("package foo\n\ntype Bar struct {"))
+	return errors.New("failed")
+}
+
+func TestPrint_Failure(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "failure")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	existing := []byte(generatedHeader + "\n\npackage foo\n")
+	p := filepath.Join(dir, DefaultFileName)
+	r.NoError(ioutil.WriteFile(p, existing, 0644))
+
+	err = Print(context.Background(), failingPrinter{}, map[string][]gen.Plan{
+		"example.com/foo": {&composite.StructPlan{TypeInfo: gen.TypeInfo{GoPath: "example.com/foo", Name: "Bar"}}},
+	}, [][2]string{{"example.com/foo", dir}}, Config{})
+	r.Error(err)
+
+	got, err := ioutil.ReadFile(p)
+	r.NoError(err)
+	r.Equal(string(existing), string(got), "existing file should be left in place")
+}
@@ -5,15 +5,34 @@ import (
 	"context"
 	"fmt"
 	"github.com/ns1/jsonschema2go/pkg/gen"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
 	"io"
+	"path"
+	"strings"
 	"text/template"
 )
 
 var baseImports = []string{"fmt"} // used for error messaging
 
+// Errors determines how the code in a generated file refers to validation error types.
+type Errors int
+
+const (
+	// SharedErrors uses the exported types of the shared runtime package at gen.SharedErrorsGoPath.
+	SharedErrors Errors = iota
+	// LocalErrors renders the package's own unexported validation error types into the file.
+	LocalErrors
+	// LocalErrorsElsewhere uses the package's own validation error types, which are rendered into another file.
+	LocalErrorsElsewhere
+)
+
+// Printer renders plans belonging to a single Go package into a file.
 type Printer interface {
-	Print(ctx context.Context, w io.Writer, goPath string, plans []gen.Plan) error
+	Print(ctx context.Context, w io.Writer, goPath string, plans []gen.Plan, errs Errors) error
 }
 
 //go:generate go run ../cmd/embedtmpl/embedtmpl.go print values.tmpl tmpl.gen.go
@@ -28,9 +47,15 @@ type printer struct {
 	tmpl *template.Template
 }
 
-func (p *printer) Print(ctx context.Context, w io.Writer, goPath string, plans []gen.Plan) error {
+func (p *printer) Print(ctx context.Context, w io.Writer, goPath string, plans []gen.Plan, errs Errors) error {
 	depPaths := make([]string, len(baseImports))
 	copy(depPaths, baseImports)
+	switch errs {
+	case SharedErrors:
+		depPaths = append(depPaths, gen.SharedErrorsGoPath)
+	case LocalErrors:
+		depPaths = append(depPaths, "errors", "strings") // used to unwrap errors and escape instance locations
+	}
 	for _, pl := range plans {
 		for _, d := range pl.Deps() {
 			depPaths = append(depPaths, d.GoPath)
@@ -39,10 +64,16 @@ func (p *printer) Print(ctx context.Context, w io.Writer, goPath string, plans [
 	imps := gen.NewImports(goPath, depPaths)
 
 	var buf bytes.Buffer
-	if err := p.tmpl.Execute(&buf, &Plans{imps, defaultSort(plans)}); err != nil {
+	if err := p.tmpl.Execute(&buf, &Plans{imps, defaultSort(plans), errs == LocalErrors}); err != nil {
 		return fmt.Errorf("unable to execute tmpl: %w", err)
 	}
 	formatted, err := format.Source(buf.Bytes())
+	if err == nil {
+		formatted, err = pruneImports(formatted)
+	}
+	if err == nil && gen.TargetGoVersion(ctx).Generics() {
+		formatted, err = useAny(formatted)
+	}
 	if err != nil {
 		if gen.IsDebug(ctx) {
 			_, _ = w.Write(buf.Bytes()) // write unformatted for debugging
@@ -57,4 +88,101 @@ func (p *printer) Print(ctx context.Context, w io.Writer, goPath string, plans [
 type Plans struct {
 	Imports *gen.Imports
 	Plans   []gen.Plan
+	Helpers bool
+}
+
+// pruneImports removes any imports not referenced by the source; plans declare their dependencies conservatively,
+// so a file holding only some of a package's plans may not use all of them.
+func pruneImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	pruned := false
+	decls := f.Decls[:0]
+	for _, decl := range f.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+		specs := d.Specs[:0]
+		for _, spec := range d.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if imp.Name != nil && (imp.Name.Name == "_" || imp.Name.Name == ".") {
+				// imported for side effects or into the file scope, so never referenced by a selector
+				specs = append(specs, spec)
+				continue
+			}
+			name := path.Base(strings.Trim(imp.Path.Value, `"`))
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if !used[name] {
+				pruned = true
+				continue
+			}
+			specs = append(specs, spec)
+		}
+		d.Specs = specs
+		if len(specs) > 0 { // an empty import declaration is valid but untidy
+			decls = append(decls, d)
+		}
+	}
+	f.Decls = decls
+	if !pruned {
+		return src, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// useAny replaces each empty interface type in the source with `any`, which is available from Go 1.18
+func useAny(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+
+	var (
+		out  bytes.Buffer
+		last int            // the offset in src up to which out has been written
+		toks [3]token.Token // the most recent tokens, in order
+		offs [3]int         // the offsets of the most recent tokens
+	)
+	for {
+		pos, tok, _ := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		copy(toks[:], toks[1:])
+		copy(offs[:], offs[1:])
+		toks[2], offs[2] = tok, file.Offset(pos)
+		if toks == [3]token.Token{token.INTERFACE, token.LBRACE, token.RBRACE} {
+			out.Write(src[last:offs[0]])
+			out.WriteString("any")
+			last = offs[2] + 1
+		}
+	}
+	if s.ErrorCount > 0 {
+		return nil, fmt.Errorf("unable to scan source")
+	}
+	out.Write(src[last:])
+	return format.Source(out.Bytes())
 }
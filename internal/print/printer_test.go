@@ -5,6 +5,7 @@ import (
 	"context"
 	"github.com/ns1/jsonschema2go/internal/composite"
 	"github.com/ns1/jsonschema2go/internal/slice"
+	"github.com/ns1/jsonschema2go/internal/validator"
 	"github.com/ns1/jsonschema2go/pkg/gen"
 	"github.com/stretchr/testify/require"
 	"go/format"
@@ -32,8 +33,8 @@ func TestImports_List(t *testing.T) {
 				"github.com/ns1/jsonschema2go/foo/example",
 			},
 			[]gen.Import{
-				{"github.com/ns1/jsonschema2go/example", ""},
-				{"github.com/ns1/jsonschema2go/foo/example", "example2"},
+				{GoPath: "github.com/ns1/jsonschema2go/example"},
+				{GoPath: "github.com/ns1/jsonschema2go/foo/example", Alias: "example2"},
 			},
 		},
 		{
@@ -41,7 +42,7 @@ func TestImports_List(t *testing.T) {
 			"github.com/ns1/jsonschema2go",
 			[]string{"encoding/json", "encoding/json"},
 			[]gen.Import{
-				{"encoding/json", ""},
+				{GoPath: "encoding/json"},
 			},
 		},
 	}
@@ -103,11 +104,15 @@ type valErr interface {
 	JSONPath() []interface{}
 	Path() []interface{}
 	Message() string
+	KeywordLocation() string
+	AbsoluteKeywordLocation() string
+	InstanceLocation() string
 }
 
 type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
+	errType, message                         string
+	jsonPath, path                           []interface{}
+	keywordLocation, absoluteKeywordLocation string
 }
 
 func (e *validationError) ErrType() string {
@@ -126,11 +131,50 @@ func (e *validationError) Message() string {
 	return e.message
 }
 
+func (e *validationError) KeywordLocation() string {
+	return e.keywordLocation
+}
+
+func (e *validationError) AbsoluteKeywordLocation() string {
+	return e.absoluteKeywordLocation
+}
+
+func (e *validationError) InstanceLocation() string {
+	var b strings.Builder
+	for _, p := range e.jsonPath {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(p)))
+	}
+	return b.String()
+}
+
 func (e *validationError) Error() string {
 	return fmt.Sprintf("%v: %v", e.path, e.message)
 }
 
 var _ valErr = new(validationError)
+
+func prefixValidationError(err error, path, jsonPath interface{}, keywordLocation string) error {
+	var vErr valErr
+	if !errors.As(err, &vErr) {
+		return err
+	}
+	return &validationError{
+		errType:                 vErr.ErrType(),
+		message:                 vErr.Message(),
+		path:                    prependPath(path, vErr.Path()),
+		jsonPath:                prependPath(jsonPath, vErr.JSONPath()),
+		keywordLocation:         keywordLocation + vErr.KeywordLocation(),
+		absoluteKeywordLocation: vErr.AbsoluteKeywordLocation(),
+	}
+}
+
+func prependPath(elem interface{}, path []interface{}) []interface{} {
+	if elem == nil {
+		return append([]interface{}(nil), path...)
+	}
+	return append([]interface{}{elem}, path...)
+}
 `
 
 	tests := []struct {
@@ -159,7 +203,9 @@ var _ valErr = new(validationError)
 package jsonschema2go
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // Bob is generated from <nil>
@@ -201,8 +247,10 @@ func (m *Bob) Validate() error {
 package jsonschema2go
 
 import (
+	"errors"
 	"fmt"
 	"github.com/ns1/jsonschema2go/blah"
+	"strings"
 )
 
 // Bob is generated from <nil>
@@ -255,9 +303,11 @@ func (m *Bob) Validate() error {
 package jsonschema2go
 
 import (
+	"errors"
 	"fmt"
 	"github.com/ns1/jsonschema2go/blah"
 	blah2 "github.com/ns1/jsonschema2go/bob/blah"
+	"strings"
 )
 
 // Bob is generated from <nil>
@@ -299,8 +349,10 @@ func (m *Bob) Validate() error {
 package jsonschema2go
 
 import (
+	"errors"
 	"fmt"
 	"github.com/ns1/jsonschema2go/blah"
+	"strings"
 )
 
 // Bob is generated from <nil>
@@ -349,7 +401,9 @@ func (m *Bob) Validate() error {
 package jsonschema2go
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // Bob is generated from <nil>
@@ -406,7 +460,9 @@ func (m *OtherType) Validate() error {
 package jsonschema2go
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // OtherType is generated from <nil>
@@ -435,7 +491,7 @@ func (m Bob) Validate() error {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var w bytes.Buffer
-			err := New(nil).Print(gen.SetDebug(context.Background()), &w, tt.goPath, tt.plans)
+			err := New(nil).Print(gen.SetDebug(context.Background()), &w, tt.goPath, tt.plans, LocalErrors)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("printStruct() error = %v, wantErr %v, output: %s", err, tt.wantErr, w.String())
 			}
@@ -452,6 +508,201 @@ func (m Bob) Validate() error {
 	}
 }
 
+func TestPrintFile_SharedErrors(t *testing.T) {
+	r := require.New(t)
+
+	var w bytes.Buffer
+	r.NoError(New(nil).Print(context.Background(), &w, "github.com/ns1/jsonschema2go", []gen.Plan{
+		&composite.StructPlan{
+			Fields: []composite.StructField{
+				{
+					Name:     "Count",
+					JSONName: "count",
+					Type:     gen.TypeInfo{Name: "int64", Pointer: true},
+					Tag:      tag(`json:"count,omitempty"`),
+					Required: true,
+				},
+			},
+			TypeInfo: gen.TypeInfo{Name: "Bob"},
+		},
+	}, SharedErrors))
+
+	want, err := format.Source([]byte(`
+// Code generated by jsonschema2go. DO NOT EDIT.
+package jsonschema2go
+
+import (
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
+)
+
+// Bob is generated from <nil>
+type Bob struct {
+	Count *int64 ` + tag(`json:"count,omitempty"`) + `
+}
+
+// Validate returns an error if this value is invalid according to rules defined in <nil>
+func (m *Bob) Validate() error {
+	if m.Count == nil {
+		return &jsvalidate.ValidationError{
+			ErrType:         "required",
+			Path:            []interface{}{"Count"},
+			JSONPath:        []interface{}{"count"},
+			Message:         "field required",
+			KeywordLocation: "/required",
+		}
+	}
+	return nil
+}
+`))
+	r.NoError(err)
+	r.Equal(string(want), w.String())
+}
+
+func TestPrintFile_LocalErrors(t *testing.T) {
+	r := require.New(t)
+
+	var w bytes.Buffer
+	r.NoError(New(nil).Print(context.Background(), &w, "github.com/ns1/jsonschema2go", []gen.Plan{
+		&composite.StructPlan{
+			Fields: []composite.StructField{
+				{
+					Name:     "Count",
+					JSONName: "count",
+					Type:     gen.TypeInfo{Name: "int64", Pointer: true},
+					Tag:      tag(`json:"count,omitempty"`),
+					Required: true,
+				},
+				{
+					Name:            "Other",
+					JSONName:        "other",
+					Type:            gen.TypeInfo{GoPath: "github.com/ns1/jsonschema2go", Name: "Other"},
+					Tag:             tag(`json:"other,omitempty"`),
+					FieldValidators: []validator.Validator{validator.SubschemaValidator},
+				},
+			},
+			TypeInfo: gen.TypeInfo{Name: "Bob"},
+		},
+	}, LocalErrorsElsewhere))
+
+	want, err := format.Source([]byte(`
+// Code generated by jsonschema2go. DO NOT EDIT.
+package jsonschema2go
+
+// Bob is generated from <nil>
+type Bob struct {
+	Count *int64 ` + tag(`json:"count,omitempty"`) + `
+	Other Other  ` + tag(`json:"other,omitempty"`) + `
+}
+
+// Validate returns an error if this value is invalid according to rules defined in <nil>
+func (m *Bob) Validate() error {
+	if m.Count == nil {
+		return &validationError{
+			errType:         "required",
+			path:            []interface{}{"Count"},
+			jsonPath:        []interface{}{"count"},
+			message:         "field required",
+			keywordLocation: "/required",
+		}
+	}
+	if err := m.Other.Validate(); err != nil {
+		return prefixValidationError(err, "Other", "other", "/properties/other")
+	}
+	return nil
+}
+`))
+	r.NoError(err)
+	r.Equal(string(want), w.String())
+}
+
 func tag(s string) string {
 	return "`" + s + "`"
 }
+
+func Test_useAny(t *testing.T) {
+	r := require.New(t)
+
+	got, err := useAny([]byte(`package foo
+
+// Foo holds an interface{}
+type Foo struct {
+	Value interface{}
+	Items map[string]interface{}
+	Path  []interface{}
+}
+
+func (m *Foo) ApplyDefaults() {
+	if v, ok := interface{}(m.Value).(interface{ ApplyDefaults() }); ok {
+		v.ApplyDefaults()
+	}
+	m.Path = []interface {
+	}{"a"}
+}
+`))
+	r.NoError(err)
+	r.Equal(`package foo
+
+// Foo holds an interface{}
+type Foo struct {
+	Value any
+	Items map[string]any
+	Path  []any
+}
+
+func (m *Foo) ApplyDefaults() {
+	if v, ok := any(m.Value).(interface{ ApplyDefaults() }); ok {
+		v.ApplyDefaults()
+	}
+	m.Path = []any{"a"}
+}
+`, string(got))
+}
+
+func Test_pruneImports(t *testing.T) {
+	r := require.New(t)
+
+	got, err := pruneImports([]byte(`package foo
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	. "strings"
+)
+
+func Foo() error {
+	return fmt.Errorf("%s", ToUpper("foo"))
+}
+`))
+	r.NoError(err)
+	r.Equal(`package foo
+
+import (
+	_ "embed"
+	"fmt"
+	. "strings"
+)
+
+func Foo() error {
+	return fmt.Errorf("%s", ToUpper("foo"))
+}
+`, string(got))
+}
+
+func Test_pruneImports_all(t *testing.T) {
+	r := require.New(t)
+
+	got, err := pruneImports([]byte(`package foo
+
+import (
+	"fmt"
+)
+
+type Foo struct{}
+`))
+	r.NoError(err)
+	r.Equal(`package foo
+
+type Foo struct{}
+`, string(got))
+}
@@ -21,16 +21,21 @@ import (
 {{ .Execute $.Imports }}
 {{ end -}}
 
+{{ if and .Helpers (not .Imports.SharedErrors) -}}
 type valErr interface {
     ErrType() string
     JSONPath() []interface{}
     Path() []interface{}
     Message() string
+    KeywordLocation() string
+    AbsoluteKeywordLocation() string
+    InstanceLocation() string
 }
 
 type validationError struct {
     errType, message string
     jsonPath, path []interface{}
+    keywordLocation, absoluteKeywordLocation string
 }
 
 func (e *validationError) ErrType() string {
@@ -49,9 +54,49 @@ func (e *validationError) Message() string {
     return e.message
 }
 
+func (e *validationError) KeywordLocation() string {
+    return e.keywordLocation
+}
+
+func (e *validationError) AbsoluteKeywordLocation() string {
+    return e.absoluteKeywordLocation
+}
+
+func (e *validationError) InstanceLocation() string {
+    var b strings.Builder
+    for _, p := range e.jsonPath {
+        b.WriteByte('/')
+        b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(p)))
+    }
+    return b.String()
+}
+
 func (e *validationError) Error() string {
     return fmt.Sprintf("%v: %v", e.path, e.message)
 }
 
 var _ valErr = new(validationError)
+
+func prefixValidationError(err error, path, jsonPath interface{}, keywordLocation string) error {
+    var vErr valErr
+    if !errors.As(err, &vErr) {
+        return err
+    }
+    return &validationError{
+        errType: vErr.ErrType(),
+        message: vErr.Message(),
+        path: prependPath(path, vErr.Path()),
+        jsonPath: prependPath(jsonPath, vErr.JSONPath()),
+        keywordLocation: keywordLocation + vErr.KeywordLocation(),
+        absoluteKeywordLocation: vErr.AbsoluteKeywordLocation(),
+    }
+}
+
+func prependPath(elem interface{}, path []interface{}) []interface{} {
+    if elem == nil {
+        return append([]interface{}(nil), path...)
+    }
+    return append([]interface{}{elem}, path...)
+}
+{{ end -}}
 `))
@@ -33,8 +33,8 @@ func Build(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan
 			return nil, err
 		}
 	}
-	a := Plan{TypeInfo: tInfo, ID: schema.ID}
-	a.Comment = schema.Annotations.GetString("description")
+	a := Plan{TypeInfo: tInfo, ID: schema.ID, itemRef: itemSchema != nil && schema.Items.Items.IsRef()}
+	a.Comment = helper.Comment(schema)
 	if itemSchema != nil {
 		typ, err := helper.DetectSimpleType(ctx, itemSchema)
 		if err != nil {
@@ -62,6 +62,8 @@ func Build(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan
 		minItemsS := strconv.FormatUint(schema.MinItems, 10)
 		a.validators = append(a.validators, validator.Validator{
 			Name:     "minItems",
+			Keyword:  "minItems",
+			SchemaID: schema.ID,
 			TestExpr: validator.TemplateStr(`len({{ .QualifiedName }}) < ` + minItemsS),
 			SprintfExpr: validator.TemplateStr(
 				`"must have length greater than ` + minItemsS + ` but was %d", len({{ .QualifiedName }})`,
@@ -72,6 +74,8 @@ func Build(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan
 		maxItemsS := strconv.FormatUint(*schema.MaxItems, 10)
 		a.validators = append(a.validators, validator.Validator{
 			Name:     "maxItems",
+			Keyword:  "maxItems",
+			SchemaID: schema.ID,
 			TestExpr: validator.TemplateStr(`len({{ .QualifiedName }}) > ` + maxItemsS),
 			SprintfExpr: validator.TemplateStr(
 				`"must have length less than ` + maxItemsS + ` but was %d", len({{ .QualifiedName }})`,
@@ -82,10 +86,14 @@ func Build(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.Plan
 		if a.ItemType.Name == "interface{}" {
 			return nil, errors.New("cannot take unique items of unhashable type")
 		}
-		a.validators = append(a.validators, validator.Validator{Name: "uniqueItems"})
+		a.validators = append(a.validators, validator.Validator{
+			Name:     "uniqueItems",
+			Keyword:  "uniqueItems",
+			SchemaID: schema.ID,
+		})
 	}
 	if itemSchema != nil {
-		a.itemValidators = validator.Validators(itemSchema)
+		a.itemValidators = validator.Validators(ctx, itemSchema)
 	}
 	return &a, nil
 }
@@ -99,6 +107,8 @@ type Plan struct {
 	ItemType       gen.TypeInfo
 	validators     []validator.Validator
 	itemValidators []validator.Validator
+	itemRef        bool
+	itemDefaults   bool
 }
 
 // Type returns the TypeInfo for this plan
@@ -127,6 +137,32 @@ func (p *Plan) ItemValidators() []validator.Validator {
 	return p.itemValidators
 }
 
+// ItemsPointer returns a JSON pointer to the schema of the items relative to the slice's schema, following any $ref
+func (p *Plan) ItemsPointer() string {
+	if p.itemRef {
+		return "/items/$ref"
+	}
+	return "/items"
+}
+
+// ApplyDefaults returns whether the items of this slice apply default values of their own
+func (p *Plan) ApplyDefaults() bool {
+	return p.itemDefaults
+}
+
+// Defaults returns the type of the slice's items, which may have default values of their own
+func (p *Plan) Defaults() (own bool, held []gen.TypeInfo) {
+	if p.ItemType.BuiltIn() {
+		return false, nil
+	}
+	return false, []gen.TypeInfo{p.ItemType}
+}
+
+// SetDefaults records whether the slice's items apply their own default values
+func (p *Plan) SetDefaults(applies func(gen.TypeInfo) bool) {
+	p.itemDefaults = !p.ItemType.BuiltIn() && applies(p.ItemType)
+}
+
 // ItemValidateInitialize returns whether there are any item validators which require initialization
 func (p *Plan) ItemValidateInitialize() bool {
 	for _, i := range p.itemValidators {
@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/array_enum/foo/bar.json
@@ -17,47 +18,15 @@ var (
 func (m Bar) Validate() error {
 	for i := range m {
 		if !barItemsEnum[m[i]] {
-			return &validationError{
-				errType:  "enum",
-				message:  fmt.Sprintf(`must be one of ("A", "B", "C") but got %v`, m[i]),
-				path:     []interface{}{i},
-				jsonPath: []interface{}{i},
+			return &jsvalidate.ValidationError{
+				ErrType:                 "enum",
+				Path:                    []interface{}{i},
+				JSONPath:                []interface{}{i},
+				Message:                 fmt.Sprintf(`must be one of ("A", "B", "C") but got %v`, m[i]),
+				KeywordLocation:         "/items/enum",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/array_enum/foo/bar.json#/items/enum",
 			}
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -2,7 +2,7 @@
 package foo
 
 import (
-	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Example is generated from https://example.com/testdata/generate/array_field/foo/example.json
@@ -13,15 +13,7 @@ type Example struct {
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/array_field/foo/example.json
 func (m *Example) Validate() error {
 	if err := m.Options.Validate(); err != nil {
-		if err, ok := err.(valErr); ok {
-			return &validationError{
-				errType:  err.ErrType(),
-				message:  err.Message(),
-				path:     append([]interface{}{"Options"}, err.Path()...),
-				jsonPath: append([]interface{}{"options"}, err.JSONPath()...),
-			}
-		}
-		return err
+		return jsvalidate.Prefix(err, "Options", "options", "/properties/options")
 	}
 	return nil
 }
@@ -44,50 +36,8 @@ type ExampleOptions []*Inner
 func (m ExampleOptions) Validate() error {
 	for i := range m {
 		if err := m[i].Validate(); err != nil {
-			if err, ok := err.(valErr); ok {
-				return &validationError{
-					errType:  err.ErrType(),
-					message:  err.Message(),
-					path:     append([]interface{}{i}, err.Path()...),
-					jsonPath: append([]interface{}{i}, err.JSONPath()...),
-				}
-			}
-			return err
+			return jsvalidate.Prefix(err, i, i, "/items/$ref")
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
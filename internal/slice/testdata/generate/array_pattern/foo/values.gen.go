@@ -3,6 +3,7 @@ package foo
 
 import (
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 )
 
 // Bar is generated from https://example.com/testdata/generate/array_pattern/foo/bar.json
@@ -17,55 +18,25 @@ var (
 func (m Bar) Validate() error {
 	for i := range m {
 		if len(m[i]) < 3 {
-			return &validationError{
-				errType:  "minLength",
-				message:  fmt.Sprintf("must have length greater than 3 but was %d", len(m[i])),
-				path:     []interface{}{i},
-				jsonPath: []interface{}{i},
+			return &jsvalidate.ValidationError{
+				ErrType:                 "minLength",
+				Path:                    []interface{}{i},
+				JSONPath:                []interface{}{i},
+				Message:                 fmt.Sprintf("must have length greater than 3 but was %d", len(m[i])),
+				KeywordLocation:         "/items/minLength",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/array_pattern/foo/bar.json#/items/minLength",
 			}
 		}
 		if !barItemsPattern.MatchString(m[i]) {
-			return &validationError{
-				errType:  "pattern",
-				message:  fmt.Sprintf(`must match '^[a-z]{10}$' but got %q`, m[i]),
-				path:     []interface{}{i},
-				jsonPath: []interface{}{i},
+			return &jsvalidate.ValidationError{
+				ErrType:                 "pattern",
+				Path:                    []interface{}{i},
+				JSONPath:                []interface{}{i},
+				Message:                 fmt.Sprintf(`must match '^[a-z]{10}$' but got %q`, m[i]),
+				KeywordLocation:         "/items/pattern",
+				AbsoluteKeywordLocation: "https://example.com/testdata/generate/array_pattern/foo/bar.json#/items/pattern",
 			}
 		}
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
@@ -30,21 +30,13 @@ func (m {{ $.Type.Name }}) Validate() error {
     seen := make(map[{{$.QualName $.ItemType}}]bool)
     for i, v := range m {
         if seen[v] {
-            return &validationError{
-                errType: "uniqueItems",
-                message: fmt.Sprintf("items must be unique but %v occurs more than once", v),
-                path: []interface{}{i},
-                jsonPath: []interface{}{i},
-            }
+            return {{ $.ValidationError "uniqueItems" ` + "`" + `fmt.Sprintf("items must be unique but %v occurs more than once", v)` + "`" + ` "i" "i" ` + "`" + `"/uniqueItems"` + "`" + ` .AbsoluteKeywordLocation }}
         }
         seen[v] = true
     }
 {{ else -}}
 	if {{ .Test (.NameSpace $.Type.Name) "m" }} {
-		return &validationError{
-			errType: "{{ .Name }}",
-			message: fmt.Sprintf({{ .Sprintf (.NameSpace $.Type.Name) "m" }}),
-		}
+		return {{ $.ValidationError .Name (print "fmt.Sprintf(" (.Sprintf (.NameSpace $.Type.Name) "m") ")") "" "" (.KeywordLocation "") .AbsoluteKeywordLocation }}
 	}
 {{ end -}}
 {{ end -}}
@@ -53,24 +45,11 @@ func (m {{ $.Type.Name }}) Validate() error {
         {{ range . -}}
         {{ if eq .Name "subschema" -}}
         if err := m[i].Validate(); err != nil {
-            if err, ok := err.(valErr); ok {
-                return &validationError{
-                    errType: err.ErrType(),
-                    message: err.Message(),
-                    path: append([]interface{}{i}, err.Path()...),
-                    jsonPath: append([]interface{}{i}, err.JSONPath()...),
-                }
-            }
-            return err
+            return {{ $.ErrorPrefix }}(err, i, i, "{{ $.ItemsPointer }}")
         }
         {{ else -}}
         if {{ .Test (.NameSpace $.Type.Name "Items") "m[i]" }} {
-            return &validationError{
-                errType: "{{ .Name }}",
-                message: fmt.Sprintf({{ .Sprintf (.NameSpace $.Type.Name "Items") "m[i]" }}),
-                path: []interface{}{i},
-                jsonPath: []interface{}{i},
-            }
+            return {{ $.ValidationError .Name (print "fmt.Sprintf(" (.Sprintf (.NameSpace $.Type.Name "Items") "m[i]") ")") "i" "i" (.KeywordLocation $.ItemsPointer) .AbsoluteKeywordLocation }}
         }
         {{ end -}}
         {{ end -}}
@@ -78,4 +57,20 @@ func (m {{ $.Type.Name }}) Validate() error {
 {{ end -}}
 	return nil
 }
+{{ if .ApplyDefaults }}
+// ApplyDefaults sets any unset fields of this slice's items to the default values defined in {{ .ID }}, returning an error if one fails to decode
+func (m {{ $.Type.Name }}) ApplyDefaults() error {
+    for i := range m {
+{{ if .ItemType.Pointer -}}
+        if m[i] == nil {
+            continue
+        }
+{{ end -}}
+        if err := m[i].ApplyDefaults(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+{{ end -}}
 `))
@@ -71,6 +71,7 @@ type TupleItem struct {
 	Comment    string
 	Type       gen.TypeInfo
 	validators []validator.Validator
+	ref        bool
 }
 
 func (t TupleItem) Validators() []validator.Validator {
@@ -94,7 +95,8 @@ func PlanTuple(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.
 	}
 
 	var items []*TupleItem
-	for _, s := range schemas {
+	for i, s := range schemas {
+		ref := schema.Items.TupleFields[i].IsRef()
 		t, err := helper.TypeInfo(s)
 		if err != nil {
 			return nil, err
@@ -102,9 +104,10 @@ func PlanTuple(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.
 		if t.Unknown() {
 			t.Name = "interface{}"
 			items = append(items, &TupleItem{
-				Comment:    s.Annotations.GetString("description"),
+				Comment:    helper.Comment(s),
 				Type:       t,
 				validators: []validator.Validator{validator.SubschemaValidator},
+				ref:        ref,
 			})
 			continue
 		}
@@ -113,17 +116,18 @@ func PlanTuple(ctx context.Context, helper gen.Helper, schema *gen.Schema) (gen.
 				return nil, err
 			}
 		}
-		vals := validator.Validators(s)
+		vals := validator.Validators(ctx, s)
 		items = append(items, &TupleItem{
-			Comment:    s.Annotations.GetString("description"),
+			Comment:    helper.Comment(s),
 			Type:       t,
 			validators: vals,
+			ref:        ref,
 		})
 	}
 
 	return &TuplePlan{
 		typeInfo: tInfo,
-		Comment:  schema.Annotations.GetString("description"),
+		Comment:  helper.Comment(schema),
 		id:       schema.ID,
 		Items:    items,
 	}, nil
@@ -154,6 +158,15 @@ func (e *EnrichedTupleItem) NameSpace() string {
 	return name
 }
 
+// Pointer returns a JSON pointer to the schema of this item relative to the tuple's schema, following any $ref
+func (e *EnrichedTupleItem) Pointer() string {
+	pointer := fmt.Sprintf("/items/%d", e.idx)
+	if e.ref {
+		pointer += "/$ref"
+	}
+	return pointer
+}
+
 func (t *TuplePlanContext) Comment() string {
 	return gen.NormalizeComment(t.TuplePlan.Comment)
 }
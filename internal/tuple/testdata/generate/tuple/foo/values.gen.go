@@ -4,6 +4,7 @@ package foo
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
@@ -18,33 +19,41 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/tuple/foo/bar.json
 func (t *Bar) Validate() error {
 	if v, ok := m[0].(string); !ok {
-		return &validationError{
-			errType:  "type",
-			path:     []interface{}{0},
-			jsonPath: []interface{}{0},
-			message:  fmt.Sprintf("must be string but got %T", m[0]),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "type",
+			Path:                    []interface{}{0},
+			JSONPath:                []interface{}{0},
+			Message:                 fmt.Sprintf("must be string but got %T", m[0]),
+			KeywordLocation:         "/items/0/type",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple/foo/bar.json#/items/0/type",
 		}
 	} else if !bar0Pattern.MatchString(v) {
-		return &validationError{
-			errType:  "pattern",
-			path:     []interface{}{0},
-			jsonPath: []interface{}{0},
-			message:  fmt.Sprintf(`must match '^abcdef$' but got %q`, v),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "pattern",
+			Path:                    []interface{}{0},
+			JSONPath:                []interface{}{0},
+			Message:                 fmt.Sprintf(`must match '^abcdef$' but got %q`, v),
+			KeywordLocation:         "/items/0/pattern",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple/foo/bar.json#/items/0/pattern",
 		}
 	}
 	if v, ok := m[1].(float64); !ok {
-		return &validationError{
-			errType:  "type",
-			path:     []interface{}{1},
-			jsonPath: []interface{}{1},
-			message:  fmt.Sprintf("must be float64 but got %T", m[1]),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "type",
+			Path:                    []interface{}{1},
+			JSONPath:                []interface{}{1},
+			Message:                 fmt.Sprintf("must be float64 but got %T", m[1]),
+			KeywordLocation:         "/items/1/type",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple/foo/bar.json#/items/1/type",
 		}
 	} else if v < 42.3 {
-		return &validationError{
-			errType:  "minimum",
-			path:     []interface{}{1},
-			jsonPath: []interface{}{1},
-			message:  fmt.Sprintf("must be greater than or equal to 42.3 but was %v", v),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimum",
+			Path:                    []interface{}{1},
+			JSONPath:                []interface{}{1},
+			Message:                 fmt.Sprintf("must be greater than or equal to 42.3 but was %v", v),
+			KeywordLocation:         "/items/1/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple/foo/bar.json#/items/1/minimum",
 		}
 	}
 	return nil
@@ -71,37 +80,3 @@ func (t *Bar) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
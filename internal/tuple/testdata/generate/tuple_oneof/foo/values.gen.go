@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
 	"regexp"
 )
 
@@ -15,6 +16,16 @@ type Baz struct {
 
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/tuple_oneof/foo/bar.json#/items/2
 func (m *Baz) Validate() error {
+	switch m.Value.(type) {
+	case int64, bool:
+	default:
+		return &jsvalidate.ValidationError{
+			ErrType:                 "oneOf",
+			Message:                 fmt.Sprintf("unsupported type: %T", m.Value),
+			KeywordLocation:         "/oneOf",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple_oneof/foo/bar.json#/items/2/oneOf",
+		}
+	}
 	return nil
 }
 
@@ -46,6 +57,51 @@ func (m *Baz) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.Value)
 }
 
+// BazKind identifies the type of value held by a Baz
+type BazKind int
+
+const (
+	// BazKindNull is the kind of a Baz holding null, or no value
+	BazKindNull BazKind = iota
+	// BazKindInt64 is the kind of a Baz holding a value of type int64
+	BazKindInt64
+	// BazKindBool is the kind of a Baz holding a value of type bool
+	BazKindBool
+)
+
+// Kind returns the type of value held
+func (m *Baz) Kind() BazKind {
+	switch m.Value.(type) {
+	case int64:
+		return BazKindInt64
+	case bool:
+		return BazKindBool
+	}
+	return BazKindNull
+}
+
+// AsInt64 returns the value and true if it is of type int64
+func (m *Baz) AsInt64() (int64, bool) {
+	v, ok := m.Value.(int64)
+	return v, ok
+}
+
+// BazFromInt64 returns a Baz holding the provided value
+func BazFromInt64(v int64) *Baz {
+	return &Baz{Value: v}
+}
+
+// AsBool returns the value and true if it is of type bool
+func (m *Baz) AsBool() (bool, bool) {
+	v, ok := m.Value.(bool)
+	return v, ok
+}
+
+// BazFromBool returns a Baz holding the provided value
+func BazFromBool(v bool) *Baz {
+	return &Baz{Value: v}
+}
+
 // Bar is generated from https://example.com/testdata/generate/tuple_oneof/foo/bar.json
 // Bar gives you some dumb info
 type Bar [3]interface{}
@@ -57,33 +113,41 @@ var (
 // Validate returns an error if this value is invalid according to rules defined in https://example.com/testdata/generate/tuple_oneof/foo/bar.json
 func (t *Bar) Validate() error {
 	if v, ok := m[0].(string); !ok {
-		return &validationError{
-			errType:  "type",
-			path:     []interface{}{0},
-			jsonPath: []interface{}{0},
-			message:  fmt.Sprintf("must be string but got %T", m[0]),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "type",
+			Path:                    []interface{}{0},
+			JSONPath:                []interface{}{0},
+			Message:                 fmt.Sprintf("must be string but got %T", m[0]),
+			KeywordLocation:         "/items/0/type",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple_oneof/foo/bar.json#/items/0/type",
 		}
 	} else if !bar0Pattern.MatchString(v) {
-		return &validationError{
-			errType:  "pattern",
-			path:     []interface{}{0},
-			jsonPath: []interface{}{0},
-			message:  fmt.Sprintf(`must match '^abcdef$' but got %q`, v),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "pattern",
+			Path:                    []interface{}{0},
+			JSONPath:                []interface{}{0},
+			Message:                 fmt.Sprintf(`must match '^abcdef$' but got %q`, v),
+			KeywordLocation:         "/items/0/pattern",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple_oneof/foo/bar.json#/items/0/pattern",
 		}
 	}
 	if v, ok := m[1].(float64); !ok {
-		return &validationError{
-			errType:  "type",
-			path:     []interface{}{1},
-			jsonPath: []interface{}{1},
-			message:  fmt.Sprintf("must be float64 but got %T", m[1]),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "type",
+			Path:                    []interface{}{1},
+			JSONPath:                []interface{}{1},
+			Message:                 fmt.Sprintf("must be float64 but got %T", m[1]),
+			KeywordLocation:         "/items/1/type",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple_oneof/foo/bar.json#/items/1/type",
 		}
 	} else if v < 42.3 {
-		return &validationError{
-			errType:  "minimum",
-			path:     []interface{}{1},
-			jsonPath: []interface{}{1},
-			message:  fmt.Sprintf("must be greater than or equal to 42.3 but was %v", v),
+		return &jsvalidate.ValidationError{
+			ErrType:                 "minimum",
+			Path:                    []interface{}{1},
+			JSONPath:                []interface{}{1},
+			Message:                 fmt.Sprintf("must be greater than or equal to 42.3 but was %v", v),
+			KeywordLocation:         "/items/1/minimum",
+			AbsoluteKeywordLocation: "https://example.com/testdata/generate/tuple_oneof/foo/bar.json#/items/1/minimum",
 		}
 	}
 	return nil
@@ -117,37 +181,3 @@ func (t *Bar) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
-
-type valErr interface {
-	ErrType() string
-	JSONPath() []interface{}
-	Path() []interface{}
-	Message() string
-}
-
-type validationError struct {
-	errType, message string
-	jsonPath, path   []interface{}
-}
-
-func (e *validationError) ErrType() string {
-	return e.errType
-}
-
-func (e *validationError) JSONPath() []interface{} {
-	return e.jsonPath
-}
-
-func (e *validationError) Path() []interface{} {
-	return e.path
-}
-
-func (e *validationError) Message() string {
-	return e.message
-}
-
-func (e *validationError) Error() string {
-	return fmt.Sprintf("%v: %v", e.path, e.message)
-}
-
-var _ valErr = new(validationError)
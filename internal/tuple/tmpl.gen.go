@@ -37,19 +37,9 @@ func (t *{{ .Type.Name }}) Validate() error {
         }
 {{ else -}}
         if v, ok := m[{{ $idx }}].({{ .ImpliedType }}); !ok {
-            return &validationError{
-                errType: "type",
-                path: []interface{}{ {{ $idx }} },
-                jsonPath: []interface{}{ {{ $idx }} },
-                message: fmt.Sprintf("must be {{ .ImpliedType }} but got %T", m[{{ $idx }}]),
-            }
+            return {{ $.ValidationError "type" (printf "fmt.Sprintf(%q, m[%d])" (print "must be " .ImpliedType " but got %T") $idx) (print $idx) (print $idx) (printf "%q" (print $Item.Pointer "/type")) (.AbsoluteLocation "/type") }}
         } else if {{ .Test ($Item.NameSpace) "v" }} {
-            return &validationError{
-                errType: "{{ .Name }}",
-                path: []interface{}{ {{ $idx }} },
-                jsonPath: []interface{}{ {{ $idx }} },
-                message: fmt.Sprintf({{ .Sprintf ($Item.NameSpace) "v" }}),
-            }
+            return {{ $.ValidationError .Name (print "fmt.Sprintf(" (.Sprintf ($Item.NameSpace) "v") ")") (print $idx) (print $idx) (.KeywordLocation $Item.Pointer) .AbsoluteKeywordLocation }}
         }
 {{ end -}}
 {{ end -}}
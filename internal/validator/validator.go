@@ -2,8 +2,10 @@ package validator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,12 +19,62 @@ var SubschemaValidator = Validator{Name: "subschema", ImpliedType: "interface {
 
 type Validator struct {
 	Name                           string
+	Keyword                        string   // the JSON Schema keyword which is validated
+	SchemaID                       *url.URL // the ID of the schema containing the keyword
 	VarExpr, TestExpr, SprintfExpr *template.Template
 	Deps                           []gen.TypeInfo
 	ImpliedType                    string
+	Location                       string // a JSON pointer to the schema applying the keyword, e.g. a oneOf's branch
+	Operand                        string // a format applied to the validated expression, e.g. to re-encode it
+	Null                           bool   // whether the validated expression is whether the value is null
 }
 
-func Validators(schema *gen.Schema) (styles []Validator) {
+// NotNull returns a validator which rejects null, for a value which records whether it is null
+func NotNull(schema *gen.Schema) Validator {
+	return Validator{
+		Name:        "type",
+		Keyword:     "type",
+		SchemaID:    schema.ID,
+		TestExpr:    TemplateStr("{{ .QualifiedName }}"),
+		SprintfExpr: TemplateStr(`"must not be null"`),
+		Null:        true,
+	}
+}
+
+// Base64 returns the validators adapted to a field holding the decoded bytes of base64 encoded content. Validators
+// of the string itself are applied to the re-encoded value; validators of the content are unchanged.
+func Base64(validators []Validator) []Validator {
+	adapted := make([]Validator, 0, len(validators))
+	for _, v := range validators {
+		if v.ImpliedType == "string" && v.Keyword != "contentMediaType" {
+			v.Operand = "base64.StdEncoding.EncodeToString(%s)"
+			v.Deps = append(v.Deps, gen.TypeInfo{GoPath: "encoding/base64", Name: "StdEncoding"})
+		}
+		adapted = append(adapted, v)
+	}
+	return adapted
+}
+
+// Content returns the validators adapted to a field holding the JSON document embedded in a string as
+// json.RawMessage. Validators of the string are applied to the document's text.
+func Content(validators []Validator) []Validator {
+	adapted := make([]Validator, 0, len(validators))
+	for _, v := range validators {
+		if v.ImpliedType == "string" && v.Keyword != "contentMediaType" {
+			v.Operand = "string(%s)"
+		}
+		adapted = append(adapted, v)
+	}
+	return adapted
+}
+
+// IsJSONMediaType returns whether the provided media type describes a JSON document
+func IsJSONMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func Validators(ctx context.Context, schema *gen.Schema) (styles []Validator) {
 	switch typ := schema.ChooseType(); typ {
 	case gen.JSONArray, gen.JSONObject:
 		if !schema.Config.NoValidate && schema.AdditionalProperties == nil {
@@ -33,6 +85,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 			pattern := *schema.Pattern
 			styles = append(styles, Validator{
 				Name:        "pattern",
+				Keyword:     "pattern",
 				VarExpr:     TemplateStr("{{ .NameSpace }}Pattern = regexp.MustCompile(`" + pattern + "`)"),
 				TestExpr:    TemplateStr("!{{ .NameSpace }}Pattern.MatchString({{ .QualifiedName }})"),
 				SprintfExpr: TemplateStr("`must match '" + pattern + "' but got %q`, {{ .QualifiedName }}"),
@@ -45,6 +98,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 				lenStr := strconv.FormatUint(schema.MinLength, 10)
 				styles = append(styles, Validator{
 					Name:     "length",
+					Keyword:  "minLength",
 					TestExpr: TemplateStr(`len({{.QualifiedName}}) != ` + lenStr),
 					SprintfExpr: TemplateStr(
 						`"must have length exactly equal to ` + lenStr + ` but was %d", len({{ .QualifiedName }})`,
@@ -55,6 +109,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 				lenStr := strconv.FormatUint(schema.MinLength, 10)
 				styles = append(styles, Validator{
 					Name:     "minLength",
+					Keyword:  "minLength",
 					TestExpr: TemplateStr(`len({{ .QualifiedName }}) < ` + lenStr),
 					SprintfExpr: TemplateStr(
 						`"must have length greater than ` + lenStr + ` but was %d", len({{ .QualifiedName }})`,
@@ -67,6 +122,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 			lenStr := strconv.FormatUint(*schema.MaxLength, 10)
 			styles = append(styles, Validator{
 				Name:     "maxLength",
+				Keyword:  "maxLength",
 				TestExpr: TemplateStr(`len({{ .QualifiedName }}) > ` + lenStr),
 				SprintfExpr: TemplateStr(
 					`"must have length less than ` + lenStr + ` but was %d", len({{ .QualifiedName }})`,
@@ -74,6 +130,18 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 				ImpliedType: "string",
 			})
 		}
+		if IsJSONMediaType(schema.ContentMediaType) {
+			// the message is a format string, so any % of the media type is escaped
+			mediaType := strings.ReplaceAll(schema.ContentMediaType, "%", "%%")
+			styles = append(styles, Validator{
+				Name:        "contentMediaType",
+				Keyword:     "contentMediaType",
+				TestExpr:    TemplateStr(`!json.Valid([]byte({{ .QualifiedName }}))`),
+				SprintfExpr: TemplateStr(strconv.Quote("must contain a valid " + mediaType + " document")),
+				Deps:        []gen.TypeInfo{{GoPath: "encoding/json", Name: "Valid"}},
+				ImpliedType: "string",
+			})
+		}
 	case gen.JSONInteger, gen.JSONNumber:
 		impliedType := "int64"
 		if typ == gen.JSONNumber {
@@ -91,13 +159,14 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 
 			styles = append(styles, Validator{
 				Name:        "multipleOf",
+				Keyword:     "multipleOf",
 				TestExpr:    expr,
 				SprintfExpr: TemplateStr(`"must be a multiple of ` + multipleOf + ` but was %v", {{ .QualifiedName }}`),
 				Deps:        deps,
 				ImpliedType: impliedType,
 			})
 		}
-		numValidator := func(name, comparator, english string, limit float64, exclusive bool) {
+		numValidator := func(name, keyword, comparator, english string, limit float64, exclusive bool) {
 			if exclusive {
 				name += "Exclusive"
 				comparator += "="
@@ -107,6 +176,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 			sLimit := fmt.Sprintf("%v", limit)
 			styles = append(styles, Validator{
 				Name:        name,
+				Keyword:     keyword,
 				TestExpr:    TemplateStr(`{{ .QualifiedName }} ` + comparator + sLimit),
 				SprintfExpr: TemplateStr(`"must be ` + english + ` ` + sLimit + ` but was %v", {{ .QualifiedName }}`),
 				ImpliedType: impliedType,
@@ -115,6 +185,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 
 		var exclusiveMin, exclusiveMax bool
 		var min, max *float64
+		minKeyword, maxKeyword := "minimum", "maximum"
 
 		min = schema.Minimum
 		if schema.ExclusiveMinimum != nil {
@@ -127,16 +198,18 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 			case float64:
 				exclusiveMin = true
 				min = &v
+				minKeyword = "exclusiveMinimum"
 			case bool:
 				exclusiveMin = v
 			default:
-				fmt.Println("Unknown type for ExclusiveMinimum")
+				gen.Log(ctx).Warn("ignoring exclusiveMinimum which is neither a number nor a boolean", "schema", schema)
 			}
 		}
 
 		if min != nil {
 			numValidator(
 				"minimum",
+				minKeyword,
 				"<",
 				"greater than",
 				*min,
@@ -155,16 +228,18 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 			case float64:
 				exclusiveMax = true
 				max = &v
+				maxKeyword = "exclusiveMaximum"
 			case bool:
 				exclusiveMax = v
 			default:
-				fmt.Println("Unknown type for ExclusiveMaximum")
+				gen.Log(ctx).Warn("ignoring exclusiveMaximum which is neither a number nor a boolean", "schema", schema)
 			}
 		}
 
 		if max != nil {
 			numValidator(
 				"maximum",
+				maxKeyword,
 				">",
 				"less than",
 				*max,
@@ -225,6 +300,7 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 
 			styles = append(styles, Validator{
 				Name:        "enum",
+				Keyword:     "enum",
 				VarExpr:     TemplateStr("{{ .NameSpace }}Enum = " + fmt.Sprintf("%#v", m)),
 				TestExpr:    TemplateStr("!{{ .NameSpace }}Enum[{{ .QualifiedName }}]"),
 				SprintfExpr: sprintfExpr,
@@ -232,9 +308,51 @@ func Validators(schema *gen.Schema) (styles []Validator) {
 			})
 		}
 	}
+
+	for i := range styles {
+		if styles[i].Keyword != "" {
+			styles[i].SchemaID = schema.ID
+		}
+	}
 	return
 }
 
+// KeywordLocation returns a quoted JSON pointer to this validator's keyword within the schema at the provided JSON
+// pointer prefix
+func (v *Validator) KeywordLocation(prefix string) string {
+	if v.Keyword == "" {
+		return strconv.Quote(prefix)
+	}
+	return strconv.Quote(prefix + "/" + v.Keyword)
+}
+
+// AbsoluteKeywordLocation returns the quoted absolute URI of this validator's keyword, or an empty string if unknown
+func (v *Validator) AbsoluteKeywordLocation() string {
+	return v.AbsoluteLocation("/" + v.Keyword)
+}
+
+// AbsoluteLocation returns the quoted absolute URI of the JSON pointer relative to the schema containing this
+// validator's keyword, or an empty string if unknown
+func (v *Validator) AbsoluteLocation(pointer string) string {
+	if v.SchemaID == nil {
+		return ""
+	}
+	return strconv.Quote(AbsoluteLocation(v.SchemaID, pointer))
+}
+
+// AbsoluteLocation returns the absolute URI of the JSON pointer relative to the schema with the provided ID
+func AbsoluteLocation(id *url.URL, pointer string) string {
+	u := *id
+	u.Fragment = strings.TrimSuffix(u.Fragment, "/") + pointer
+	u.RawFragment = ""
+	return u.String()
+}
+
+// PointerToken escapes a string for use as a token within a JSON pointer, per RFC 6901
+func PointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
 func (v *Validator) Var(nameSpace string) (string, error) {
 	return tmplString(v.VarExpr, struct {
 		NameSpace string
@@ -244,13 +362,20 @@ func (v *Validator) Var(nameSpace string) (string, error) {
 func (v *Validator) Test(nameSpace, qualifiedName string) (string, error) {
 	return tmplString(v.TestExpr, struct {
 		NameSpace, QualifiedName string
-	}{nameSpace, qualifiedName})
+	}{nameSpace, v.operand(qualifiedName)})
 }
 
 func (v *Validator) Sprintf(nameSpace, qualifiedName string) (string, error) {
 	return tmplString(v.SprintfExpr, struct {
 		NameSpace, QualifiedName string
-	}{nameSpace, qualifiedName})
+	}{nameSpace, v.operand(qualifiedName)})
+}
+
+func (v *Validator) operand(qualifiedName string) string {
+	if v.Operand == "" {
+		return qualifiedName
+	}
+	return fmt.Sprintf(v.Operand, qualifiedName)
 }
 
 func (Validator) NameSpace(names ...interface{}) string {
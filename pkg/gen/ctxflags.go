@@ -1,6 +1,12 @@
 package gen
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
 
 // SetDebug sets a flag in the context indicating that debug mode has been enabled. The value may be accessed with
 // IsDebug
@@ -14,8 +20,100 @@ func IsDebug(ctx context.Context) bool {
 	return ok && b
 }
 
+// SetSelfContained sets a flag in the context indicating that generated code mustn't depend on jsonschema2go. The value
+// may be accessed with IsSelfContained
+func SetSelfContained(ctx context.Context) context.Context {
+	return context.WithValue(ctx, selfContainedCtxKey, true)
+}
+
+// IsSelfContained returns whether or not the self-contained flag has been set to true in this context.
+func IsSelfContained(ctx context.Context) bool {
+	b, ok := ctx.Value(selfContainedCtxKey).(bool)
+	return ok && b
+}
+
+// Logger receives leveled, structured log messages. The args are alternating keys and values, as accepted by
+// log/slog, so a *slog.Logger may be used directly.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// SetLogger sets the logger in the context which receives messages about generation. It may be accessed with Log.
+func SetLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, l)
+}
+
+// Log returns the logger set in this context. If none was set, messages are written with the standard log package
+// when the debug flag is set and discarded otherwise.
+func Log(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerCtxKey).(Logger); ok {
+		return l
+	}
+	if IsDebug(ctx) {
+		return stdLogger{}
+	}
+	return nopLogger{}
+}
+
+// SetGoVersion sets the Go version targeted by generated code in the context. It may be accessed with
+// TargetGoVersion.
+func SetGoVersion(ctx context.Context, v GoVersion) context.Context {
+	return context.WithValue(ctx, goVersionCtxKey, v)
+}
+
+// TargetGoVersion returns the Go version targeted by generated code set in this context, or the zero value if none
+// was set.
+func TargetGoVersion(ctx context.Context) GoVersion {
+	v, _ := ctx.Value(goVersionCtxKey).(GoVersion)
+	return v
+}
+
 type ctxKey int
 
 const (
 	debugCtxKey ctxKey = iota
+	loggerCtxKey
+	goVersionCtxKey
+	selfContainedCtxKey
 )
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// stdLogger writes messages with the standard log package, formatting args as key=value pairs
+type stdLogger struct{}
+
+func (stdLogger) Debug(msg string, args ...interface{}) { logStd("DEBUG", msg, args) }
+func (stdLogger) Info(msg string, args ...interface{})  { logStd("INFO", msg, args) }
+func (stdLogger) Warn(msg string, args ...interface{})  { logStd("WARN", msg, args) }
+func (stdLogger) Error(msg string, args ...interface{}) { logStd("ERROR", msg, args) }
+
+func logStd(level, msg string, args []interface{}) {
+	log.Print(formatLog(level, msg, args))
+}
+
+func formatLog(level, msg string, args []interface{}) string {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		key, val := "!BADKEY", args[i]
+		if i+1 < len(args) {
+			key, val = fmt.Sprint(args[i]), args[i+1]
+		}
+		v := fmt.Sprint(val)
+		if v == "" || strings.ContainsAny(v, " =\"\t\n") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, v)
+	}
+	return b.String()
+}
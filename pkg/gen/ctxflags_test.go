@@ -0,0 +1,47 @@
+package gen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	nopLogger
+	msgs []string
+}
+
+func (r *recordingLogger) Debug(msg string, args ...interface{}) {
+	r.msgs = append(r.msgs, formatLog("DEBUG", msg, args))
+}
+
+func TestLog(t *testing.T) {
+	r := require.New(t)
+
+	ctx := context.Background()
+	r.Equal(nopLogger{}, Log(ctx))
+	r.Equal(stdLogger{}, Log(SetDebug(ctx)))
+
+	l := &recordingLogger{}
+	Log(SetLogger(SetDebug(ctx), l)).Debug("checking planner", "planner", "map", "schema", "https://example.com/foo.json")
+	r.Equal([]string{"DEBUG checking planner planner=map schema=https://example.com/foo.json"}, l.msgs)
+}
+
+func Test_formatLog(t *testing.T) {
+	tests := []struct {
+		name string
+		args []interface{}
+		want string
+	}{
+		{name: "no args", want: "INFO msg"},
+		{name: "pairs", args: []interface{}{"file", "/a/b.go", "plans", 3}, want: "INFO msg file=/a/b.go plans=3"},
+		{name: "quoted", args: []interface{}{"reason", "not an object", "empty", ""}, want: `INFO msg reason="not an object" empty=""`},
+		{name: "odd", args: []interface{}{"file", "a.go", "dangling"}, want: "INFO msg file=a.go !BADKEY=dangling"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, formatLog("INFO", "msg", tt.args))
+		})
+	}
+}
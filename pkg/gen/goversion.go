@@ -0,0 +1,53 @@
+package gen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GoVersion is a Go language version, such as 1.18, which generated code may target to use newer language features
+// and standard library packages. The zero value targets the oldest supported version.
+type GoVersion struct {
+	Major, Minor int
+}
+
+// ParseGoVersion parses a Go language version of the form "1.18" or "go1.18". Any patch version is ignored.
+func ParseGoVersion(s string) (GoVersion, error) {
+	parts := strings.SplitN(strings.TrimPrefix(s, "go"), ".", 3)
+	if len(parts) < 2 {
+		return GoVersion{}, fmt.Errorf("invalid go version %q", s)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 1 {
+		return GoVersion{}, fmt.Errorf("invalid go version %q", s)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return GoVersion{}, fmt.Errorf("invalid go version %q", s)
+	}
+	return GoVersion{Major: major, Minor: minor}, nil
+}
+
+// AtLeast returns whether this version is the provided version or later
+func (v GoVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// Generics returns whether code targeting this version may use type parameters and `any`, which require Go 1.18
+func (v GoVersion) Generics() bool {
+	return v.AtLeast(1, 18)
+}
+
+// Iterators returns whether code targeting this version may use range-over-func iterators, such as maps.Keys and
+// slices.Sorted, which require Go 1.23
+func (v GoVersion) Iterators() bool {
+	return v.AtLeast(1, 23)
+}
+
+func (v GoVersion) String() string {
+	if v == (GoVersion{}) {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
@@ -0,0 +1,48 @@
+package gen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    GoVersion
+		wantErr bool
+	}{
+		{in: "1.18", want: GoVersion{1, 18}},
+		{in: "go1.23", want: GoVersion{1, 23}},
+		{in: "1.21.4", want: GoVersion{1, 21}},
+		{in: "1", wantErr: true},
+		{in: "latest", wantErr: true},
+		{in: "1.x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			r := require.New(t)
+			got, err := ParseGoVersion(tt.in)
+			if tt.wantErr {
+				r.Error(err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestGoVersion_AtLeast(t *testing.T) {
+	r := require.New(t)
+	r.False(GoVersion{}.Generics())
+	r.False(GoVersion{1, 17}.Generics())
+	r.True(GoVersion{1, 18}.Generics())
+	r.False(GoVersion{1, 22}.Iterators())
+	r.True(GoVersion{1, 23}.Iterators())
+	r.True(GoVersion{2, 0}.Iterators())
+
+	r.Equal(GoVersion{}, TargetGoVersion(context.Background()))
+	r.Equal(GoVersion{1, 21}, TargetGoVersion(SetGoVersion(context.Background(), GoVersion{1, 21})))
+}
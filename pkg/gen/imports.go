@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 // Imports encapsulates knowledge about the current imports and namespace. It provides utilities for generating
@@ -53,6 +55,63 @@ func NewImports(currentGoPath string, importGoPaths []string) *Imports {
 	return &Imports{currentGoPath, aliases}
 }
 
+// SharedErrorsGoPath is the Go path of the runtime package containing the validation error types shared by generated
+// code.
+const SharedErrorsGoPath = "github.com/ns1/jsonschema2go/pkg/jsvalidate"
+
+// SharedErrors returns the qualifier with which generated code should refer to the shared validation error types, or
+// an empty string if the package instead renders its own. The shared types are used whenever SharedErrorsGoPath is
+// among the imports.
+func (i *Imports) SharedErrors() string {
+	alias, ok := i.aliases[SharedErrorsGoPath]
+	switch {
+	case !ok:
+		return ""
+	case alias != "":
+		return alias
+	}
+	return path.Base(SharedErrorsGoPath)
+}
+
+// ErrorPrefix returns the name of the function with which generated code should prefix the paths and keyword location
+// of an error returned by a nested value: the shared Prefix, or the package's own prefixValidationError.
+func (i *Imports) ErrorPrefix() string {
+	if shared := i.SharedErrors(); shared != "" {
+		return shared + ".Prefix"
+	}
+	return "prefixValidationError"
+}
+
+// ValidationError returns an expression constructing a validation error of the provided type: a shared
+// ValidationError, or the package's own validationError. The other arguments are Go expressions for the message, the
+// sole element of the Go and JSON paths, and the keyword locations; any which are empty are left unset.
+func (i *Imports) ValidationError(
+	errType, message, path, jsonPath, keywordLocation, absoluteKeywordLocation string,
+) string {
+	typ := "validationError"
+	names := [...]string{"errType", "path", "jsonPath", "message", "keywordLocation", "absoluteKeywordLocation"}
+	if shared := i.SharedErrors(); shared != "" {
+		typ = shared + ".ValidationError"
+		names = [...]string{"ErrType", "Path", "JSONPath", "Message", "KeywordLocation", "AbsoluteKeywordLocation"}
+	}
+	if path != "" {
+		path = "[]interface{}{" + path + "}"
+	}
+	if jsonPath != "" {
+		jsonPath = "[]interface{}{" + jsonPath + "}"
+	}
+
+	var b strings.Builder
+	b.WriteString("&" + typ + "{\n")
+	for j, v := range [...]string{strconv.Quote(errType), path, jsonPath, message, keywordLocation, absoluteKeywordLocation} {
+		if v != "" {
+			b.WriteString(names[j] + ": " + v + ",\n")
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
 // CurPackage the current package for this Imports
 func (i *Imports) CurPackage() string {
 	return path.Base(i.currentGoPath)
@@ -0,0 +1,392 @@
+package gen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Diagnostic describes a problem with a specific node of a schema document
+type Diagnostic struct {
+	Src     *url.URL // the resource the document was loaded from
+	Pointer string   // a JSON pointer to the offending node within the document
+	Pos     Position // the line and column of the offending node, if known
+	Message string
+}
+
+// Location returns the file path or URL of the document, the line and column of the offending node if known, and the
+// JSON pointer to it
+func (d Diagnostic) Location() string {
+	src := "<unknown>"
+	if d.Src != nil {
+		u := *d.Src
+		u.Fragment, u.RawFragment = "", ""
+		if src = u.String(); u.Scheme == "file" {
+			src = u.Path
+		}
+	}
+	if d.Pos.IsValid() {
+		src += ":" + d.Pos.String()
+	}
+	return src + "#" + d.Pointer
+}
+
+func (d Diagnostic) String() string {
+	return d.Location() + ": " + d.Message
+}
+
+// Diagnostics is a list of problems with schema documents, reported together as an error
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	msgs := make([]string, 0, len(d))
+	for _, diag := range d {
+		msgs = append(msgs, diag.String())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Lint checks the raw JSON of a schema document loaded from src against the rules which the draft-07 metaschema sets
+// for the values of the keywords jsonschema2go reads, returning a diagnostic for every keyword with an invalid value.
+// The rules are a hand-written subset of the metaschema, rather than validation against it, and are stricter in places
+// where jsonschema2go would otherwise fail, e.g. a pattern must compile with Go's regexp package. Other keywords,
+// whether unknown or without rules of their own such as const and default, are permitted with any value.
+func Lint(src *url.URL, data []byte) Diagnostics {
+	doc, err := scanDocument(data)
+	if err != nil {
+		return Diagnostics{invalidJSON(src, doc, err)}
+	}
+	return lint(src, doc)
+}
+
+func lint(src *url.URL, doc *document) Diagnostics {
+	l := linter{src: src, pos: doc.pos}
+	l.schema("", doc.value)
+	return l.diags
+}
+
+func invalidJSON(src *url.URL, doc *document, err error) Diagnostic {
+	d := Diagnostic{Src: src, Message: fmt.Sprintf("invalid JSON: %v", err)}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		d.Pos = doc.pos.at(int(syntaxErr.Offset) - 1) // the offset is just past the offending byte
+	}
+	return d
+}
+
+// LoadAll loads the schemas at the provided URLs along with every schema they reference, directly or transitively, and
+// lints the documents read by the base loader, so that problems with any of them are reported before planning starts.
+// The diagnostics of all malformed documents are returned together.
+func LoadAll(ctx context.Context, loader Loader, urls []*url.URL) error {
+	var (
+		diags   Diagnostics
+		seen    = make(map[string]bool)
+		pending []*Schema
+	)
+	load := func(u *url.URL) error {
+		doc := *u
+		doc.Fragment, doc.RawFragment = "", ""
+		if seen[doc.String()] {
+			return nil
+		}
+		seen[doc.String()] = true
+
+		s, err := loader.Load(ctx, u)
+		var d Diagnostics
+		if errors.As(err, &d) {
+			diags = append(diags, d...)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if s.doc != nil {
+			if d := lint(s.Src, s.doc); len(d) > 0 {
+				diags = append(diags, d...)
+				return nil
+			}
+		}
+		pending = append(pending, s)
+		return nil
+	}
+
+	for _, u := range urls {
+		if err := load(u); err != nil {
+			return err
+		}
+	}
+	for len(pending) > 0 {
+		s := pending[0]
+		pending = pending[1:]
+		if s.Ref != nil {
+			if err := loadRef(s, *s.Ref, load); err != nil {
+				return err
+			}
+		}
+		for _, c := range s.children() {
+			if c.ref != nil {
+				if err := loadRef(s, *c.ref, load); err != nil {
+					return err
+				}
+				continue
+			}
+			pending = append(pending, c.schema)
+		}
+	}
+	if len(diags) > 0 {
+		doc := func(d Diagnostic) string {
+			return Diagnostic{Src: d.Src}.Location()
+		}
+		sort.SliceStable(diags, func(i, j int) bool {
+			return doc(diags[i]) < doc(diags[j])
+		})
+		return diags
+	}
+	return nil
+}
+
+func loadRef(referer *Schema, ref string, load func(u *url.URL) error) error {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return fmt.Errorf("parse $ref: %w", err)
+	}
+	return load(referer.Src.ResolveReference(parsed))
+}
+
+type linter struct {
+	src   *url.URL
+	pos   *positions
+	diags Diagnostics
+}
+
+func (l *linter) errorf(pointer, format string, args ...interface{}) {
+	l.diags = append(l.diags, Diagnostic{
+		Src:     l.src,
+		Pointer: pointer,
+		Pos:     l.pos.lookup(pointer),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// schema checks a schema, which may be either an object or a boolean
+func (l *linter) schema(pointer string, v interface{}) {
+	if _, ok := v.(bool); ok {
+		return
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		l.errorf(pointer, "schema must be an object or boolean but was %s", jsonTypeName(v))
+		return
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, p := obj[k], pointer+"/"+escapePointer(k)
+		switch k {
+		case "$id", "id", "$schema", "$ref", "$comment", "title", "description", "format", "contentEncoding",
+			"contentMediaType":
+			l.string(p, v)
+		case "pattern":
+			if l.string(p, v) {
+				l.pattern(p, v.(string))
+			}
+		case "multipleOf":
+			if n, ok := l.number(p, v); ok && n <= 0 {
+				l.errorf(p, "must be greater than 0")
+			}
+		case "maximum", "minimum":
+			l.number(p, v)
+		case "exclusiveMaximum", "exclusiveMinimum":
+			if _, ok := v.(bool); !ok {
+				l.number(p, v)
+			}
+		case "maxLength", "minLength", "maxItems", "minItems", "maxProperties", "minProperties":
+			if n, ok := l.number(p, v); ok && (n < 0 || n != float64(int64(n))) {
+				l.errorf(p, "must be a non-negative integer")
+			}
+		case "uniqueItems", "nullable", "readOnly", "writeOnly":
+			if _, ok := v.(bool); !ok {
+				l.errorf(p, "must be a boolean but was %s", jsonTypeName(v))
+			}
+		case "required":
+			l.stringArray(p, v)
+		case "type":
+			l.typ(p, v)
+		case "enum", "examples":
+			if _, ok := v.([]interface{}); !ok {
+				l.errorf(p, "must be an array but was %s", jsonTypeName(v))
+			}
+		case "items":
+			if arr, ok := v.([]interface{}); ok {
+				for i, s := range arr {
+					l.schema(fmt.Sprintf("%s/%d", p, i), s)
+				}
+				break
+			}
+			l.schema(p, v)
+		case "additionalItems", "additionalProperties", "not", "contains", "propertyNames", "if", "then", "else":
+			l.schema(p, v)
+		case "properties", "definitions", "$defs":
+			l.schemaMap(p, v, false)
+		case "patternProperties":
+			l.schemaMap(p, v, true)
+		case "dependencies":
+			deps, ok := v.(map[string]interface{})
+			if !ok {
+				l.errorf(p, "must be an object but was %s", jsonTypeName(v))
+				break
+			}
+			for _, name := range sortedKeys(deps) {
+				dp := p + "/" + escapePointer(name)
+				if _, ok := deps[name].([]interface{}); ok {
+					l.stringArray(dp, deps[name])
+					continue
+				}
+				l.schema(dp, deps[name])
+			}
+		case "allOf", "anyOf", "oneOf":
+			arr, ok := v.([]interface{})
+			switch {
+			case !ok:
+				l.errorf(p, "must be an array but was %s", jsonTypeName(v))
+			case len(arr) == 0:
+				l.errorf(p, "must not be empty")
+			}
+			for i, s := range arr {
+				l.schema(fmt.Sprintf("%s/%d", p, i), s)
+			}
+		case "x-jsonschema2go":
+			if _, ok := v.(map[string]interface{}); !ok {
+				l.errorf(p, "must be an object but was %s", jsonTypeName(v))
+			}
+		}
+	}
+}
+
+func (l *linter) schemaMap(pointer string, v interface{}, patterns bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		l.errorf(pointer, "must be an object but was %s", jsonTypeName(v))
+		return
+	}
+	for _, k := range sortedKeys(m) {
+		p := pointer + "/" + escapePointer(k)
+		if patterns {
+			l.pattern(p, k)
+		}
+		l.schema(p, m[k])
+	}
+}
+
+func (l *linter) string(pointer string, v interface{}) bool {
+	if _, ok := v.(string); !ok {
+		l.errorf(pointer, "must be a string but was %s", jsonTypeName(v))
+		return false
+	}
+	return true
+}
+
+func (l *linter) number(pointer string, v interface{}) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		l.errorf(pointer, "must be a number but was %s", jsonTypeName(v))
+		return 0, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		l.errorf(pointer, "invalid number %v: %v", n, err)
+		return 0, false
+	}
+	return f, true
+}
+
+func (l *linter) stringArray(pointer string, v interface{}) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		l.errorf(pointer, "must be an array of strings but was %s", jsonTypeName(v))
+		return
+	}
+	seen := make(map[string]bool, len(arr))
+	for i, e := range arr {
+		s, ok := e.(string)
+		if !ok {
+			l.errorf(fmt.Sprintf("%s/%d", pointer, i), "must be a string but was %s", jsonTypeName(e))
+			continue
+		}
+		if seen[s] {
+			l.errorf(fmt.Sprintf("%s/%d", pointer, i), "duplicate value %q", s)
+		}
+		seen[s] = true
+	}
+}
+
+func (l *linter) typ(pointer string, v interface{}) {
+	check := func(p string, v interface{}) {
+		s, ok := v.(string)
+		if !ok {
+			l.errorf(p, "must be a string but was %s", jsonTypeName(v))
+			return
+		}
+		if _, ok := simpleTypeNames[s]; !ok {
+			l.errorf(p, "unknown type %q; must be one of array, boolean, integer, null, number, object or string", s)
+		}
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		check(pointer, v)
+		return
+	}
+	if len(arr) == 0 {
+		l.errorf(pointer, "must not be empty")
+	}
+	for i, e := range arr {
+		check(fmt.Sprintf("%s/%d", pointer, i), e)
+	}
+}
+
+func (l *linter) pattern(pointer, pattern string) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		l.errorf(pointer, "invalid pattern %q: %v", pattern, err)
+	}
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	case string:
+		return "a string"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
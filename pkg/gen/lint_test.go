@@ -0,0 +1,211 @@
+package gen
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	src, _ := url.Parse("file:/schemas/foo.json")
+
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "valid",
+			data: `{
+				"$id": "https://example.com/foo.json",
+				"type": ["object", "null"],
+				"properties": {
+					"a/b": {"type": "string", "pattern": "^[a-z]+$", "minLength": 1},
+					"c": {"items": [{"type": "integer"}, true], "additionalItems": false},
+					"d": {"exclusiveMinimum": true, "minimum": 3}
+				},
+				"required": ["a/b"],
+				"x-jsonschema2go": {"gopath": "example.com/foo#Foo"},
+				"unknown-keyword": 3
+			}`,
+		},
+		{
+			name: "invalid JSON",
+			data: `{"type": }`,
+			want: []string{"/schemas/foo.json:1:10#: invalid JSON: missing value after object key"},
+		},
+		{
+			name: "non-ASCII",
+			data: `{"title": "héllo wörld", "type": "strng"}`,
+			want: []string{
+				`/schemas/foo.json:1:34#/type: unknown type "strng"; must be one of array, boolean, integer, null, number, object or string`,
+			},
+		},
+		{
+			name: "unknown type",
+			data: `{"properties": {"a/b": {"type": "strng"}, "c": {"type": ["string", 3]}}}`,
+			want: []string{
+				`/schemas/foo.json:1:33#/properties/a~1b/type: unknown type "strng"; must be one of array, boolean, integer, null, number, object or string`,
+				`/schemas/foo.json:1:68#/properties/c/type/1: must be a string but was a number`,
+			},
+		},
+		{
+			name: "required as string",
+			data: `{"required": "a", "properties": {"a": {"required": ["b", "b"]}}}`,
+			want: []string{
+				`/schemas/foo.json:1:58#/properties/a/required/1: duplicate value "b"`,
+				`/schemas/foo.json:1:14#/required: must be an array of strings but was a string`,
+			},
+		},
+		{
+			name: "keyword values",
+			data: `{
+				"minLength": -1,
+				"maxItems": 1.5,
+				"multipleOf": 0,
+				"pattern": "a(?=b)",
+				"patternProperties": {"[": {}},
+				"allOf": [],
+				"items": 3,
+				"uniqueItems": "yes"
+			}`,
+			want: []string{
+				`/schemas/foo.json:7:14#/allOf: must not be empty`,
+				`/schemas/foo.json:8:14#/items: schema must be an object or boolean but was a number`,
+				`/schemas/foo.json:3:17#/maxItems: must be a non-negative integer`,
+				`/schemas/foo.json:2:18#/minLength: must be a non-negative integer`,
+				`/schemas/foo.json:4:19#/multipleOf: must be greater than 0`,
+				"/schemas/foo.json:5:16#/pattern: invalid pattern \"a(?=b)\": error parsing regexp: invalid or unsupported Perl syntax: `(?=`",
+				"/schemas/foo.json:6:32#/patternProperties/[: invalid pattern \"[\": error parsing regexp: missing closing ]: `[`",
+				`/schemas/foo.json:9:20#/uniqueItems: must be a boolean but was a string`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range Lint(src, []byte(tt.data)) {
+				got = append(got, d.String())
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLint_keywords(t *testing.T) {
+	src, _ := url.Parse("file:/schemas/foo.json")
+
+	// a malformed value for every keyword with a rule, and the pointer of the diagnostic it must produce
+	tests := []struct {
+		keyword, value, pointer string
+	}{
+		{keyword: "$id", value: `3`},
+		{keyword: "id", value: `3`},
+		{keyword: "$schema", value: `3`},
+		{keyword: "$ref", value: `3`},
+		{keyword: "$comment", value: `3`},
+		{keyword: "title", value: `3`},
+		{keyword: "description", value: `3`},
+		{keyword: "format", value: `3`},
+		{keyword: "contentEncoding", value: `3`},
+		{keyword: "contentMediaType", value: `3`},
+		{keyword: "pattern", value: `"("`},
+		{keyword: "multipleOf", value: `-2`},
+		{keyword: "maximum", value: `"3"`},
+		{keyword: "minimum", value: `"3"`},
+		{keyword: "exclusiveMaximum", value: `"3"`},
+		{keyword: "exclusiveMinimum", value: `"3"`},
+		{keyword: "maxLength", value: `-1`},
+		{keyword: "minLength", value: `1.5`},
+		{keyword: "maxItems", value: `"1"`},
+		{keyword: "minItems", value: `-1`},
+		{keyword: "maxProperties", value: `-1`},
+		{keyword: "minProperties", value: `-1`},
+		{keyword: "uniqueItems", value: `"yes"`},
+		{keyword: "nullable", value: `1`},
+		{keyword: "readOnly", value: `"yes"`},
+		{keyword: "writeOnly", value: `"yes"`},
+		{keyword: "required", value: `["a", 1]`, pointer: "/required/1"},
+		{keyword: "type", value: `"strng"`},
+		{keyword: "enum", value: `{}`},
+		{keyword: "examples", value: `"a"`},
+		{keyword: "items", value: `3`},
+		{keyword: "items", value: `[{}, 3]`, pointer: "/items/1"},
+		{keyword: "additionalItems", value: `3`},
+		{keyword: "additionalProperties", value: `"no"`},
+		{keyword: "not", value: `[]`},
+		{keyword: "contains", value: `3`},
+		{keyword: "propertyNames", value: `"^a"`},
+		{keyword: "if", value: `3`},
+		{keyword: "then", value: `3`},
+		{keyword: "else", value: `3`},
+		{keyword: "properties", value: `[]`},
+		{keyword: "properties", value: `{"a": 3}`, pointer: "/properties/a"},
+		{keyword: "definitions", value: `{"a": {"type": 3}}`, pointer: "/definitions/a/type"},
+		{keyword: "$defs", value: `3`},
+		{keyword: "patternProperties", value: `{"(": {}}`, pointer: "/patternProperties/("},
+		{keyword: "dependencies", value: `["a"]`},
+		{keyword: "dependencies", value: `{"a": 3}`, pointer: "/dependencies/a"},
+		{keyword: "dependencies", value: `{"a": ["b", 3]}`, pointer: "/dependencies/a/1"},
+		{keyword: "allOf", value: `[]`},
+		{keyword: "anyOf", value: `{}`},
+		{keyword: "oneOf", value: `[{}, 3]`, pointer: "/oneOf/1"},
+		{keyword: "x-jsonschema2go", value: `"Foo"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.keyword+" "+tt.value, func(t *testing.T) {
+			pointer := tt.pointer
+			if pointer == "" {
+				pointer = "/" + tt.keyword
+			}
+			diags := Lint(src, []byte(`{"`+tt.keyword+`": `+tt.value+`}`))
+			require.Len(t, diags, 1, "%v", diags)
+			require.Equal(t, pointer, diags[0].Pointer)
+		})
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	r := require.New(t)
+
+	dir, err := ioutil.TempDir("", "loadall")
+	r.NoError(err)
+	defer os.RemoveAll(dir)
+
+	for name, data := range map[string]string{
+		"root.json":  `{"id": "https://example.com/root.json", "properties": {"a": {"$ref": "a.json"}, "b": {"$ref": "b.json#/definitions/b"}}}`,
+		"a.json":     `{"id": "https://example.com/a.json", "type": "strng", "items": {"$ref": "b.json"}}`,
+		"b.json":     `{"id": "https://example.com/b.json", "definitions": {"b": {"required": "c"}}}`,
+		"valid.json": `{"id": "https://example.com/valid.json", "type": "string"}`,
+		"ecma.json":  `{"id": "https://example.com/ecma.json", "type": "string", "pattern": "^(?!a)"}`,
+	} {
+		r.NoError(ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+	}
+	mustURL := func(name string) *url.URL {
+		u, err := url.Parse("file:" + filepath.Join(dir, name))
+		r.NoError(err)
+		return u
+	}
+
+	r.NoError(LoadAll(context.Background(), NewLoader(), []*url.URL{mustURL("valid.json")}))
+
+	// only LoadAll lints documents which can be decoded
+	_, err = NewLoader().Load(context.Background(), mustURL("ecma.json"))
+	r.NoError(err)
+	err = LoadAll(context.Background(), NewLoader(), []*url.URL{mustURL("ecma.json")})
+	r.Error(err)
+	r.Contains(err.Error(), "#/pattern")
+
+	err = LoadAll(context.Background(), NewLoader(), []*url.URL{mustURL("root.json")})
+	var diags Diagnostics
+	r.True(errors.As(err, &diags), "expected diagnostics but got %v", err)
+	r.Len(diags, 2)
+	r.Equal(filepath.Join(dir, "a.json")+":1:46#/type", diags[0].Location())
+	r.Equal(filepath.Join(dir, "b.json")+":1:72#/definitions/b/required", diags[1].Location())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
@@ -26,7 +27,8 @@ type baseLoader struct {
 	client *http.Client
 }
 
-// Load loads the requested resource from the provided URL (must be file, http, or https), times out, or errors
+// Load loads the requested resource from the provided URL (must be file, http, or https), times out, or errors. The
+// document is linted only if it can't be decoded; LoadAll lints every document.
 func (b *baseLoader) Load(ctx context.Context, src *url.URL) (*Schema, error) {
 	// open IO
 	var r io.ReadCloser
@@ -53,9 +55,21 @@ func (b *baseLoader) Load(ctx context.Context, src *url.URL) (*Schema, error) {
 		_ = r.Close()
 	}()
 
-	// read and init schema
+	// read, check and init schema
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q failed: %w", src, err)
+	}
+	doc, err := scanDocument(data)
+	if err != nil {
+		return nil, Diagnostics{invalidJSON(src, doc, err)}
+	}
 	var s Schema
-	if err := json.NewDecoder(r).Decode(&s); err != nil {
+	if err := json.Unmarshal(data, &s); err != nil {
+		// report why the document is malformed where possible
+		if diags := lint(src, doc); len(diags) > 0 {
+			return nil, diags
+		}
 		return nil, fmt.Errorf("decoding %q failed: %w", src, err)
 	}
 	if s.ID == nil {
@@ -63,6 +77,8 @@ func (b *baseLoader) Load(ctx context.Context, src *url.URL) (*Schema, error) {
 	}
 	s.calculateID()
 	s.setSrc(src)
+	s.setLocation("", doc.pos)
+	s.doc = doc
 
 	return &s, nil
 }
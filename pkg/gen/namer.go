@@ -0,0 +1,96 @@
+package gen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CommonInitialisms are the initialisms which golint expects to be consistently cased, such as URL and JSON
+var CommonInitialisms = []string{
+	"acl", "api", "ascii", "cpu", "css", "dns", "eof", "guid", "html", "http", "https", "id", "ip", "json", "lhs",
+	"qps", "ram", "rhs", "rpc", "sla", "smtp", "sql", "ssh", "tcp", "tls", "ttl", "udp", "ui", "uid", "uuid", "uri",
+	"url", "utf8", "vm", "xml", "xmpp", "xsrf", "xss",
+}
+
+// NewNamer returns a namer which upper cases the provided initialisms, in any case, when they appear as words
+func NewNamer(knownInitialisms []string) *Namer {
+	m := make(map[string]bool)
+	for _, n := range knownInitialisms {
+		m[strings.ToLower(n)] = true
+	}
+	return &Namer{m}
+}
+
+// Namer converts JSON names into Go identifiers
+type Namer struct {
+	knownInitialisms map[string]bool
+}
+
+// With returns a namer which knows the provided initialisms in addition to those of this namer
+func (n *Namer) With(initialisms ...string) *Namer {
+	known := make([]string, 0, len(n.knownInitialisms)+len(initialisms))
+	for i := range n.knownInitialisms {
+		known = append(known, i)
+	}
+	return NewNamer(append(known, initialisms...))
+}
+
+// JSONPropertyExported converts a JSON property name into an exported Go identifier. Words are split at upper case
+// letters and at any rune which may not appear in an identifier, such as punctuation or whitespace, then capitalized,
+// with known initialisms upper cased. Names which would not otherwise be exported, such as those starting with a
+// digit or an uncased letter, or which contain no letters or digits at all, are prefixed with X. Since the result is
+// exported, it is never a Go keyword.
+func (n *Namer) JSONPropertyExported(name string) string {
+	if strings.ToUpper(name) == name {
+		name = strings.ToLower(name)
+	}
+
+	var (
+		current []rune
+		parts   [][]rune
+	)
+	// split words
+	for _, r := range []rune(name) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			// exclusive word boundary
+			if len(current) != 0 {
+				parts = append(parts, current)
+				current = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) {
+			// inclusive word boundary
+			if len(current) != 0 {
+				parts = append(parts, current)
+			}
+			current = []rune{unicode.ToLower(r)}
+			continue
+		}
+
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+
+	return n.exportedIdentifier(parts)
+}
+
+func (n *Namer) exportedIdentifier(parts [][]rune) string {
+	var words []string
+	for _, rs := range parts {
+		if word := string(rs); n.knownInitialisms[word] {
+			words = append(words, strings.ToUpper(word))
+			continue
+		}
+		rs[0] = unicode.ToUpper(rs[0])
+		words = append(words, string(rs))
+	}
+	ident := strings.Join(words, "")
+	if r := []rune(ident); len(r) == 0 || !unicode.IsUpper(r[0]) {
+		ident = "X" + ident
+	}
+	return ident
+}
@@ -3,11 +3,44 @@ package gen
 import (
 	"context"
 	"errors"
+	"strings"
 )
 
 // ErrContinue can be returned from a Planner if there is no match. Other planners may then be tried.
 var ErrContinue = errors.New("continue")
 
+// PlanError is returned when no planner is able to generate a Plan for a schema. It locates the schema within its
+// document and records why each planner declined it.
+type PlanError struct {
+	Diagnostic
+	Attempts []Attempt
+}
+
+// Attempt records a planner which declined to plan a schema
+type Attempt struct {
+	Planner string
+	Reason  error
+}
+
+func (e *PlanError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Diagnostic.String())
+	for _, a := range e.Attempts {
+		b.WriteString("\n\t")
+		b.WriteString(a.String())
+	}
+	return b.String()
+}
+
+func (a Attempt) String() string {
+	return a.Planner + ": " + a.Message()
+}
+
+// Message returns why the planner declined, omitting the ErrContinue sentinel
+func (a Attempt) Message() string {
+	return strings.TrimSuffix(a.Reason.Error(), ": "+ErrContinue.Error())
+}
+
 // Plan is the contract that must be filled for a type to be rendered.
 type Plan interface {
 	// Type returns the TypeInfo for the current type
@@ -18,6 +51,26 @@ type Plan interface {
 	Execute(imports *Imports) (string, error)
 }
 
+// DefaultsPlan is implemented by plans whose types may apply default values, either declared by their own schema or by
+// the schemas of the values they hold. Once every type has been planned, SetDefaults is called with the types which
+// apply defaults.
+type DefaultsPlan interface {
+	Plan
+	// Defaults returns whether the type has default values of its own, and the types of the values it holds which may
+	// have theirs
+	Defaults() (own bool, held []TypeInfo)
+	// SetDefaults records which types apply defaults, and so must be applied by this type
+	SetDefaults(applies func(TypeInfo) bool)
+}
+
+// DeclsPlan is implemented by plans which declare package level identifiers besides their type, such as the constants
+// and constructors of a union, which mustn't collide with any other type
+type DeclsPlan interface {
+	Plan
+	// Decls returns the names of the identifiers declared besides the type
+	Decls() []string
+}
+
 // Planner is a strategy for generating a Plan from a Schema
 type Planner interface {
 	// Plan generates a Plan from a Schema. If the error matches `errors.Is(err, ErrContinue)`, processing may continue.
@@ -35,4 +88,6 @@ type Helper interface {
 	TypeInfoHinted(s *Schema, t JSONType) TypeInfo
 	JSONPropertyExported(name string) string
 	Primitive(s JSONType) string
+	Comment(s *Schema) string
+	PointerPolicy(parent, field *Schema) PointerPolicy
 }
@@ -0,0 +1,115 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Position is a line and column within a schema document, both starting at 1
+type Position struct {
+	Line, Column int
+}
+
+// IsValid returns whether the position is known
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// positions records the position at which every value of a JSON document starts, keyed by JSON pointer
+type positions struct {
+	data       []byte
+	lineStarts []int
+	pointers   map[string]Position
+}
+
+// document is a JSON document decoded as generic values, as by json.Decoder with UseNumber, along with the position at
+// which every value starts
+type document struct {
+	value interface{}
+	pos   *positions
+}
+
+// scanDocument decodes a JSON document, recording the start of every value in the same pass; if the document is
+// malformed, the error is returned along with the positions of the values preceding it.
+func scanDocument(data []byte) (*document, error) {
+	p := &positions{data: data, lineStarts: []int{0}, pointers: make(map[string]Position)}
+	for i, b := range data {
+		if b == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := p.value(dec, "")
+	return &document{value: v, pos: p}, err
+}
+
+func (p *positions) value(dec *json.Decoder, pointer string) (interface{}, error) {
+	p.pointers[pointer] = p.at(p.skip(int(dec.InputOffset())))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := make(map[string]interface{})
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			k := fmt.Sprint(key)
+			if obj[k], err = p.value(dec, pointer+"/"+escapePointer(k)); err != nil {
+				return nil, err
+			}
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := make([]interface{}, 0)
+		for i := 0; dec.More(); i++ {
+			v, err := p.value(dec, fmt.Sprintf("%s/%d", pointer, i))
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+// skip advances an offset past whitespace and separators to the start of the next token
+func (p *positions) skip(off int) int {
+	for off < len(p.data) && bytes.IndexByte([]byte(" \t\r\n:,"), p.data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+// at converts a byte offset into a position, counting columns in runes
+func (p *positions) at(off int) Position {
+	line := sort.Search(len(p.lineStarts), func(i int) bool {
+		return p.lineStarts[i] > off
+	})
+	if off > len(p.data) {
+		off = len(p.data)
+	}
+	return Position{Line: line, Column: utf8.RuneCount(p.data[p.lineStarts[line-1]:off]) + 1}
+}
+
+// lookup returns the position of the value at the JSON pointer, if known
+func (p *positions) lookup(pointer string) Position {
+	if p == nil {
+		return Position{}
+	}
+	return p.pointers[pointer]
+}
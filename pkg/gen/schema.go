@@ -45,6 +45,14 @@ var simpleTypeNames = map[string]JSONType{
 	"string":  JSONString,
 }
 
+var typeNames = make(map[JSONType]string, len(simpleTypeNames))
+
+func init() {
+	for name, typ := range simpleTypeNames {
+		typeNames[typ] = name
+	}
+}
+
 // TypeField wraps the type field in JSONSchema, supporting either an array of types or a single type as the metaschema
 // allows
 type TypeField []JSONType
@@ -73,6 +81,42 @@ func (t *TypeField) UnmarshalJSON(b []byte) error {
 	return fmt.Errorf("unable to unmarshal %T into TypeField", val)
 }
 
+// NonNull returns the types other than null
+func (t TypeField) NonNull() (types []JSONType) {
+	for _, typ := range t {
+		if typ != JSONNull {
+			types = append(types, typ)
+		}
+	}
+	return
+}
+
+// Nullable returns whether null is permitted along with another type, e.g. ["string", "null"]
+func (t TypeField) Nullable() bool {
+	return len(t.NonNull()) > 0 && len(t.NonNull()) < len(t)
+}
+
+// Union returns whether more than one type other than null is permitted, e.g. ["integer", "string"]
+func (t TypeField) Union() bool {
+	return len(t.NonNull()) > 1
+}
+
+// MarshalJSON marshals the TypeField into JSON, as a single type name if possible
+func (t TypeField) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, len(t))
+	for _, typ := range t {
+		name, ok := typeNames[typ]
+		if !ok {
+			return nil, fmt.Errorf("unable to marshal unknown type %d", typ)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 1 {
+		return json.Marshal(names[0])
+	}
+	return json.Marshal(names)
+}
+
 // convenience method to draw out the first token; if this errs, later calls will err anyway so discards
 // the err
 func peekToken(data []byte) json.Token {
@@ -100,6 +144,14 @@ func (a *BoolOrSchema) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, a.Schema)
 }
 
+// MarshalJSON marshals either the boolean or the schema
+func (a *BoolOrSchema) MarshalJSON() ([]byte, error) {
+	if a.Schema != nil {
+		return json.Marshal(a.Schema)
+	}
+	return json.Marshal(a.Bool)
+}
+
 // ItemsField contains information indicating whether the modified array is a dynamically sized list of multiple
 // types or a "tuple" -- a specifically sized array with potentially different types for each position.
 type ItemsField struct {
@@ -120,6 +172,14 @@ func (i *ItemsField) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &i.TupleFields)
 }
 
+// MarshalJSON marshals either the schema of all items or the schemas of each tuple field
+func (i *ItemsField) MarshalJSON() ([]byte, error) {
+	if i.Items != nil {
+		return json.Marshal(i.Items)
+	}
+	return json.Marshal(i.TupleFields)
+}
+
 // TagMap contains all of the different user extended tags as json.RawMessage for later deserialization
 type TagMap map[string]json.RawMessage
 
@@ -153,6 +213,11 @@ type RefOrSchema struct {
 	schema *Schema
 }
 
+// IsRef returns whether this is a reference to a schema rather than a schema itself.
+func (r *RefOrSchema) IsRef() bool {
+	return r.ref != nil
+}
+
 // UnmarshalJSON conditionally deserializes the JSON, either into a reference or a schema.
 func (r *RefOrSchema) UnmarshalJSON(b []byte) error {
 	var ref struct {
@@ -169,6 +234,16 @@ func (r *RefOrSchema) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, r.schema)
 }
 
+// MarshalJSON marshals either the reference or the schema.
+func (r *RefOrSchema) MarshalJSON() ([]byte, error) {
+	if r.ref != nil {
+		return json.Marshal(struct {
+			Ref string `json:"$ref"`
+		}{*r.ref})
+	}
+	return json.Marshal(r.schema)
+}
+
 // Resolve either returns the schema if set or else resolves the reference using the referer schema and loader.
 func (r *RefOrSchema) Resolve(ctx context.Context, referer *Schema, loader Loader) (*Schema, error) {
 	if r.ref == nil {
@@ -189,10 +264,13 @@ type Schema struct {
 	Ref *string `json:"$ref,omitempty"`
 
 	// meta
-	ID     *url.URL `json:"-"` // set either from "$id", "id", or calculated based on parent (see IDCalc); never nil
-	IDCalc bool     `json:"-"` // whether this ID was calculated
-	Src    *url.URL `json:"-"` // the resource from which this schema was loaded; never nil
-	Schema string   `json:"$schema,omitempty"`
+	ID      *url.URL `json:"-"` // set either from "$id", "id", or calculated based on parent (see IDCalc); never nil
+	IDCalc  bool     `json:"-"` // whether this ID was calculated
+	Src     *url.URL `json:"-"` // the resource from which this schema was loaded; never nil
+	Pointer string   `json:"-"` // a JSON pointer to this schema within the document it was loaded from
+	Pos     Position `json:"-"` // the position of this schema within the document it was loaded from, if known
+	Variant string   `json:"-"` // distinguishes a schema derived from another with the same ID, as a branch of a union of types is
+	Schema  string   `json:"$schema,omitempty"`
 
 	// number qualifiers
 	MultipleOf       *float64         `json:"multipleOf,omitempty"`
@@ -202,9 +280,11 @@ type Schema struct {
 	ExclusiveMinimum *json.RawMessage `json:"exclusiveMinimum,omitempty"`
 
 	// string qualifiers
-	MaxLength *uint64 `json:"maxLength,omitempty"`
-	MinLength uint64  `json:"minLength,omitempty"`
-	Pattern   *string `json:"pattern,omitempty"`
+	MaxLength        *uint64 `json:"maxLength,omitempty"`
+	MinLength        uint64  `json:"minLength,omitempty"`
+	Pattern          *string `json:"pattern,omitempty"`
+	ContentEncoding  string  `json:"contentEncoding,omitempty"`
+	ContentMediaType string  `json:"contentMediaType,omitempty"`
 
 	// array qualifiers
 	AdditionalItems *BoolOrSchema `json:"additionalItems,omitempty"`
@@ -240,25 +320,76 @@ type Schema struct {
 
 	// user extensible
 	Annotations TagMap `json:"-"`
+
+	doc *document // the decoded document, if this schema is the root of one read by the base loader, kept to be linted
 }
 
 // Config is a series of jsonschema2go user extensions
 type Config struct {
-	GoPath         string            `json:"gopath"`
-	Exclude        bool              `json:"exclude"`
+	GoPath         string            `json:"gopath,omitempty"`
+	Exclude        bool              `json:"exclude,omitempty"`
 	Discriminator  Discriminator     `json:"Discriminator"`
-	NoValidate     bool              `json:"noValidate"`
-	PromoteFields  bool              `json:"promoteFields"`
-	NoOmitEmpty    bool              `json:"noOmitEmpty"`
-	OmitEmptyArray bool              `json:"omitEmptyArray"`
-	RawMessage     bool              `json:"rawMessage"`
-	FieldAliases   map[string]string `json:"fieldAliases"`
+	NoValidate     bool              `json:"noValidate,omitempty"`
+	PromoteFields  bool              `json:"promoteFields,omitempty"`
+	NoOmitEmpty    bool              `json:"noOmitEmpty,omitempty"`
+	OmitEmptyArray bool              `json:"omitEmptyArray,omitempty"`
+	RawMessage     bool              `json:"rawMessage,omitempty"`
+	FieldAliases   map[string]string `json:"fieldAliases,omitempty"` // field names by property, used as is
+	TypeName       string            `json:"typeName,omitempty"`     // the name of the generated type, used as is
+	Pointers       PointerPolicy     `json:"pointers,omitempty"`     // how primitive fields are typed
+}
+
+// PointerPolicy determines how the string, integer, number and boolean fields of a struct are typed
+type PointerPolicy string
+
+const (
+	// PointersAlways types every primitive field as a pointer, so that an unset field is nil. This is the default.
+	PointersAlways PointerPolicy = "always"
+	// PointersOptional types optional primitive fields as pointers and required ones as values
+	PointersOptional PointerPolicy = "optional"
+	// PointersGeneric types optional primitive fields as jsoptional.Optional and required ones as values. The generated
+	// code requires Go 1.18.
+	PointersGeneric PointerPolicy = "generic"
+	// PointersNullable types primitive and object fields as jsoptional.Nullable, which distinguishes an absent value
+	// from null, so that null is rejected unless the field's schema permits it. The generated code requires Go 1.18.
+	PointersNullable PointerPolicy = "nullable"
+)
+
+// Valid returns whether this is a known policy
+func (p PointerPolicy) Valid() bool {
+	switch p {
+	case PointersAlways, PointersOptional, PointersGeneric, PointersNullable:
+		return true
+	}
+	return false
+}
+
+// Generic returns whether the policy types fields with jsoptional's generic types, which require Go 1.18
+func (p PointerPolicy) Generic() bool {
+	return p == PointersGeneric || p == PointersNullable
+}
+
+// IsZero returns whether no extensions are set
+func (c *Config) IsZero() bool {
+	return reflect.DeepEqual(*c, Config{})
+}
+
+// MarshalJSON marshals only the extensions which are set
+func (c *Config) MarshalJSON() ([]byte, error) {
+	type config Config
+	if c.Discriminator.PropertyName != "" || len(c.Discriminator.Mapping) > 0 {
+		return json.Marshal((*config)(c))
+	}
+	return json.Marshal(struct {
+		*config
+		Discriminator *Discriminator `json:"Discriminator,omitempty"`
+	}{config: (*config)(c)})
 }
 
 // Discriminator is jsonschema2go specific info for discriminating between multiple oneOf objects
 type Discriminator struct {
 	PropertyName string            `json:"propertyName"`
-	Mapping      map[string]string `json:"mapping"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
 }
 
 // IsSet returns whether there is a discriminator present.
@@ -275,6 +406,50 @@ func (s *Schema) setSrc(u *url.URL) {
 	}
 }
 
+func (s *Schema) setLocation(pointer string, pos *positions) {
+	s.Pointer, s.Pos = pointer, pos.lookup(pointer)
+	for _, c := range s.children() {
+		if c.schema == nil {
+			continue
+		}
+		childPointer := pointer
+		for _, v := range c.path {
+			childPointer += "/" + escapePointer(fmt.Sprint(v))
+		}
+		c.schema.setLocation(childPointer, pos)
+	}
+}
+
+// Descendant returns the schema at the JSON pointer within the document this schema was loaded from, or nil if there
+// is no schema there. Schemas reached through a $ref are not descendants.
+func (s *Schema) Descendant(pointer string) *Schema {
+	if s.Pointer == pointer {
+		return s
+	}
+	for _, c := range s.children() {
+		if c.schema == nil {
+			continue
+		}
+		if p := c.schema.Pointer; p == pointer || strings.HasPrefix(pointer, p+"/") {
+			if d := c.schema.Descendant(pointer); d != nil {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// Location returns the file path or URL of the document this schema was loaded from, its line and column if known,
+// and the JSON pointer to it
+func (s *Schema) Location() string {
+	return Diagnostic{Src: s.Src, Pointer: s.Pointer, Pos: s.Pos}.Location()
+}
+
+// Diagnostic returns a diagnostic locating this schema within the document it was loaded from
+func (s *Schema) Diagnostic(format string, args ...interface{}) Diagnostic {
+	return Diagnostic{Src: s.Src, Pointer: s.Pointer, Pos: s.Pos, Message: fmt.Sprintf(format, args...)}
+}
+
 func (s *Schema) calculateID() {
 	for _, c := range s.children() {
 		if c.schema == nil {
@@ -348,17 +523,25 @@ func (s *Schema) children() (children []child) {
 	return
 }
 
-// String returns a simple string identifier for the schema
+// String identifies the schema by its ID, along with its variant if any
 func (s *Schema) String() string {
 	if s.ID == nil {
 		return "<nil>"
 	}
+	if s.Variant != "" {
+		return s.ID.String() + " (" + s.Variant + ")"
+	}
 	return s.ID.String()
 }
 
-// ChooseType returns the best known type for this field.
+// ChooseType returns the best known type for this field. A type permitting null along with a single other type is
+// that type; one permitting several other types is unknown.
 func (s *Schema) ChooseType() JSONType {
 	switch {
+	case s.Type != nil && s.Type.Union():
+		return JSONUnknown
+	case s.Type != nil && s.Type.Nullable():
+		return s.Type.NonNull()[0]
 	case s.Type != nil && len(*s.Type) > 0:
 		return (*s.Type)[0]
 	case len(s.Properties) > 0,
@@ -375,7 +558,9 @@ func (s *Schema) ChooseType() JSONType {
 		return JSONArray
 	case s.Pattern != nil,
 		s.MinLength > 0,
-		s.MaxLength != nil:
+		s.MaxLength != nil,
+		s.ContentEncoding != "",
+		s.ContentMediaType != "":
 		return JSONString
 	}
 	return JSONUnknown
@@ -428,6 +613,40 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON is custom JSON serialization for the Schema type, the inverse of UnmarshalJSON. Annotations are
+// serialized alongside the known fields, and an ID which wasn't calculated is written as "$id" unless an annotation
+// already records it.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	data, err := json.Marshal((*schema)(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if s.Config.IsZero() {
+		delete(fields, "x-jsonschema2go")
+	}
+	for k, v := range s.Annotations {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+	if s.ID != nil && !s.IDCalc {
+		_, hasID := fields["$id"]
+		_, hasLegacyID := fields["id"]
+		if !hasID && !hasLegacyID {
+			if fields["$id"], err = json.Marshal(s.ID.String()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return json.Marshal(fields)
+}
+
 func getJSONFieldNames(val interface{}) (fields []string) {
 	t := reflect.TypeOf(val)
 	for i := 0; i < t.NumField(); i++ {
@@ -454,6 +673,10 @@ func NormalizeComment(s string) string {
 	}
 	var parts []string
 	for _, p := range strings.Split(s, "\n") {
+		if p == "" {
+			parts = append(parts, "//")
+			continue
+		}
 		parts = append(parts, "// "+p)
 	}
 	return strings.Join(parts, "\n")
@@ -2,6 +2,7 @@ package gen
 
 import (
 	"encoding/json"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -73,6 +74,8 @@ func Test_getJSONFieldNames(t *testing.T) {
 				"maxLength",
 				"minLength",
 				"pattern",
+				"contentEncoding",
+				"contentMediaType",
 				"additionalItems",
 				"items",
 				"maxItems",
@@ -86,6 +89,7 @@ func Test_getJSONFieldNames(t *testing.T) {
 				"properties",
 				"patternProperties",
 				"dependencies",
+				"nullable",
 				"enum",
 				"type",
 				"format",
@@ -202,6 +206,92 @@ func TestSchema_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestSchema_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "empty obj",
+			data: `{}`,
+		},
+		{
+			name: "ref",
+			data: `{"$ref": "#/definitions/foo"}`,
+		},
+		{
+			name: "type list",
+			data: `{"type": ["string", "null"]}`,
+		},
+		{
+			name: "round trip",
+			data: `{
+				"$id": "https://example.com/foo.json",
+				"title": "Foo",
+				"type": "object",
+				"properties": {
+					"bar": {"$ref": "bar.json"},
+					"baz": {
+						"type": "array",
+						"items": [{"type": "integer", "exclusiveMinimum": 3}, {"enum": ["a", "b"]}],
+						"additionalItems": false
+					},
+					"qux": {
+						"type": "object",
+						"additionalProperties": {"type": "string", "default": "hi"},
+						"x-jsonschema2go": {"noOmitEmpty": true}
+					}
+				},
+				"required": ["bar"],
+				"x-jsonschema2go": {"gopath": "example.com/foo#Foo"},
+				"x-other-extension": {"a": [1, 2]}
+			}`,
+		},
+		{
+			name: "discriminator",
+			data: `{"oneOf": [{"$ref": "a.json"}, {"$ref": "b.json"}], "x-jsonschema2go": {"Discriminator": {"propertyName": "type"}}}`,
+		},
+		{
+			name: "legacy id",
+			data: `{"id": "https://example.com/foo.json", "items": {"type": "string"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			var s Schema
+			r.NoError(json.Unmarshal([]byte(tt.data), &s))
+			if s.ID != nil {
+				s.calculateID()
+			}
+
+			got, err := json.Marshal(&s)
+			r.NoError(err)
+			want := tt.want
+			if want == "" {
+				want = tt.data
+			}
+			r.JSONEq(want, string(got))
+		})
+	}
+
+	t.Run("uncalculated ID", func(t *testing.T) {
+		r := require.New(t)
+		id, err := url.Parse("https://example.com/foo.json")
+		r.NoError(err)
+
+		got, err := json.Marshal(&Schema{ID: id, Type: &TypeField{JSONString}})
+		r.NoError(err)
+		r.JSONEq(`{"$id": "https://example.com/foo.json", "type": "string"}`, string(got))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := json.Marshal(&Schema{Type: &TypeField{JSONUnknown}})
+		require.Error(t, err)
+	})
+}
+
 func schema(s Schema) *RefOrSchema {
 	return &RefOrSchema{schema: &s}
 }
@@ -217,3 +307,27 @@ func annos(annos map[string]string) TagMap {
 	}
 	return m
 }
+
+func TestSchema_ChooseType(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want JSONType
+	}{
+		{"single", `{"type": "string"}`, JSONString},
+		{"list of one", `{"type": ["integer"]}`, JSONInteger},
+		{"nullable", `{"type": ["null", "object"]}`, JSONObject},
+		{"null", `{"type": ["null"]}`, JSONNull},
+		{"union", `{"type": ["integer", "string"]}`, JSONUnknown},
+		{"nullable union", `{"type": ["integer", "string", "null"]}`, JSONUnknown},
+		{"inferred", `{"minLength": 1}`, JSONString},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			var s Schema
+			r.NoError(json.Unmarshal([]byte(tt.data), &s))
+			r.Equal(tt.want, s.ChooseType())
+		})
+	}
+}
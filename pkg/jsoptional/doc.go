@@ -0,0 +1,3 @@
+// Package jsoptional contains the generic wrapper types used by code generated by jsonschema2go with the generic
+// pointer policy. It requires Go 1.18.
+package jsoptional
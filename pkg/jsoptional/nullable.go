@@ -0,0 +1,56 @@
+package jsoptional
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a value which may be absent or null. Decoding any value, including null, sets it; an unset or null value
+// is encoded as null, so structs containing Nullable fields omit them when unset by implementing MarshalJSON.
+type Nullable[T any] struct {
+	Value T
+	Set   bool // whether the value is present, even if null
+	Null  bool
+}
+
+// NullableValue returns a set, non-null Nullable holding v
+func NullableValue[T any](v T) Nullable[T] {
+	return Nullable[T]{Value: v, Set: true}
+}
+
+// NullValue returns a set, null Nullable
+func NullValue[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+
+// Get returns the value and whether it is set and not null
+func (n Nullable[T]) Get() (T, bool) {
+	return n.Value, n.Set && !n.Null
+}
+
+// IsZero returns whether the value is unset, allowing encoding/json to omit it with the omitzero option
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+
+// MarshalJSON encodes the value, or null if it is unset or null
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Set || n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON decodes the value and sets it, recording whether it is null
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullValue[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = NullableValue(v)
+	return nil
+}
@@ -0,0 +1,50 @@
+package jsoptional
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional is a value which may be absent. Decoding any value other than null sets it; an unset value is encoded as
+// null, so structs containing Optional fields omit them when unset by implementing MarshalJSON.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Some returns a set Optional holding v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Get returns the value and whether it is set
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+// IsZero returns whether the value is unset, allowing encoding/json to omit it with the omitzero option
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+// MarshalJSON encodes the value, or null if it is unset
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON decodes the value and sets it, unless it is null
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
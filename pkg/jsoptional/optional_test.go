@@ -0,0 +1,58 @@
+package jsoptional
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptional(t *testing.T) {
+	r := require.New(t)
+
+	var v struct {
+		A Optional[string] `json:"a"`
+		B Optional[int64]  `json:"b"`
+		C Optional[bool]   `json:"c"`
+	}
+	r.NoError(json.Unmarshal([]byte(`{"a": "", "b": null}`), &v))
+	r.Equal(Some(""), v.A)
+	r.False(v.B.Set)
+	r.False(v.C.Set)
+
+	s, ok := v.A.Get()
+	r.True(ok)
+	r.Equal("", s)
+
+	r.Error(json.Unmarshal([]byte(`{"b": "x"}`), &v))
+
+	b, err := json.Marshal(v)
+	r.NoError(err)
+	r.JSONEq(`{"a": "", "b": null, "c": null}`, string(b))
+}
+
+func TestNullable(t *testing.T) {
+	r := require.New(t)
+
+	var v struct {
+		A Nullable[string]            `json:"a"`
+		B Nullable[int64]             `json:"b"`
+		C Nullable[bool]              `json:"c"`
+		D Nullable[map[string]string] `json:"d"`
+	}
+	r.NoError(json.Unmarshal([]byte(`{"a": "", "b": null, "d": {"x": "y"}}`), &v))
+	r.Equal(NullableValue(""), v.A)
+	r.Equal(NullValue[int64](), v.B)
+	r.False(v.C.Set)
+	r.Equal(NullableValue(map[string]string{"x": "y"}), v.D)
+
+	_, ok := v.B.Get()
+	r.False(ok)
+	s, ok := v.A.Get()
+	r.True(ok)
+	r.Equal("", s)
+
+	b, err := json.Marshal(v)
+	r.NoError(err)
+	r.JSONEq(`{"a": "", "b": null, "c": null, "d": {"x": "y"}}`, string(b))
+}
@@ -0,0 +1,108 @@
+// Package jsvalidate contains the runtime types shared by code generated by jsonschema2go.
+package jsvalidate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError is returned from the Validate methods of generated types when a value does not conform to the
+// schema the type was generated from.
+type ValidationError struct {
+	// ErrType is the kind of validation which failed, e.g. "required" or "pattern"
+	ErrType string
+	// Path is the location of the invalid value as a list of Go field names and indices
+	Path []interface{}
+	// JSONPath is the location of the invalid value as a list of JSON property names and indices
+	JSONPath []interface{}
+	// Message is a human readable description of the failure
+	Message string
+	// KeywordLocation is a JSON pointer to the failing keyword, relative to the schema of the validated type and
+	// following any $ref along the way
+	KeywordLocation string
+	// AbsoluteKeywordLocation is the absolute URI of the failing keyword, i.e. the ID of the schema containing it with
+	// a JSON pointer fragment
+	AbsoluteKeywordLocation string
+}
+
+// InstanceLocation returns the location of the invalid value within the validated JSON document as an RFC 6901 JSON
+// pointer, e.g. "/foo/0/bar"
+func (e *ValidationError) InstanceLocation() string {
+	var b strings.Builder
+	for _, p := range e.JSONPath {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(fmt.Sprint(p)))
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// OutputUnit returns this error as an output unit of the JSON Schema "basic" output format
+func (e *ValidationError) OutputUnit() OutputUnit {
+	return OutputUnit{
+		KeywordLocation:         e.KeywordLocation,
+		AbsoluteKeywordLocation: e.AbsoluteKeywordLocation,
+		InstanceLocation:        e.InstanceLocation(),
+		Error:                   e.Message,
+	}
+}
+
+// Error returns a string representation of this error
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Path, e.Message)
+}
+
+// Prefix returns a copy of err with the provided elements prepended to its paths and keywordLocation prepended to its
+// keyword location, which is useful for reporting the failure of a nested value. Nil elements aren't prepended, as for
+// the value held by a union, which is validated in place. If err is not a *ValidationError, it is returned unchanged.
+func Prefix(err error, path, jsonPath interface{}, keywordLocation string) error {
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		return err
+	}
+	return &ValidationError{
+		ErrType:                 vErr.ErrType,
+		Path:                    prepend(path, vErr.Path),
+		JSONPath:                prepend(jsonPath, vErr.JSONPath),
+		Message:                 vErr.Message,
+		KeywordLocation:         keywordLocation + vErr.KeywordLocation,
+		AbsoluteKeywordLocation: vErr.AbsoluteKeywordLocation,
+	}
+}
+
+// prepend returns a copy of the path with the element prepended, unless it is nil
+func prepend(elem interface{}, path []interface{}) []interface{} {
+	if elem == nil {
+		return append([]interface{}(nil), path...)
+	}
+	return append([]interface{}{elem}, path...)
+}
+
+// OutputUnit describes a single error in the JSON Schema "basic" output format.
+type OutputUnit struct {
+	KeywordLocation         string `json:"keywordLocation"`
+	AbsoluteKeywordLocation string `json:"absoluteKeywordLocation,omitempty"`
+	InstanceLocation        string `json:"instanceLocation"`
+	Error                   string `json:"error"`
+}
+
+// Output is a validation result in the JSON Schema "basic" output format, suitable for serializing as JSON.
+type Output struct {
+	Valid  bool         `json:"valid"`
+	Errors []OutputUnit `json:"errors,omitempty"`
+}
+
+// Basic converts the error returned from a generated Validate method into the JSON Schema "basic" output format. A
+// nil error is valid, and errors which are not a *ValidationError are reported at the root of the instance.
+func Basic(err error) Output {
+	if err == nil {
+		return Output{Valid: true}
+	}
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		return Output{Errors: []OutputUnit{{Error: err.Error()}}}
+	}
+	return Output{Errors: []OutputUnit{vErr.OutputUnit()}}
+}
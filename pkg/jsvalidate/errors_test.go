@@ -0,0 +1,89 @@
+package jsvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefix(t *testing.T) {
+	r := require.New(t)
+
+	inner := &ValidationError{
+		ErrType:         "pattern",
+		Path:            []interface{}{"Name"},
+		JSONPath:        []interface{}{"name"},
+		Message:         "must match",
+		KeywordLocation: "/properties/name/pattern",
+	}
+	err := Prefix(inner, 2, 2, "/items")
+
+	var vErr *ValidationError
+	r.True(errors.As(err, &vErr))
+	r.Equal(&ValidationError{
+		ErrType:         "pattern",
+		Path:            []interface{}{2, "Name"},
+		JSONPath:        []interface{}{2, "name"},
+		Message:         "must match",
+		KeywordLocation: "/items/properties/name/pattern",
+	}, vErr)
+	r.Equal([]interface{}{"Name"}, inner.Path, "original is unmodified")
+	r.Equal("[2 Name]: must match", err.Error())
+
+	err = Prefix(inner, nil, nil, "/oneOf/1")
+	r.True(errors.As(err, &vErr))
+	r.Equal([]interface{}{"name"}, vErr.JSONPath, "nil elements aren't prepended")
+	r.Equal("/oneOf/1/properties/name/pattern", vErr.KeywordLocation)
+
+	other := fmt.Errorf("other")
+	r.Equal(other, Prefix(other, "Foo", "foo", "/properties/foo"))
+}
+
+func TestValidationError_InstanceLocation(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		jsonPath []interface{}
+		want     string
+	}{
+		{name: "root"},
+		{name: "nested", jsonPath: []interface{}{"foo", 0, "bar"}, want: "/foo/0/bar"},
+		{name: "escaped", jsonPath: []interface{}{"a/b", "m~n"}, want: "/a~1b/m~0n"},
+		{name: "empty property", jsonPath: []interface{}{""}, want: "/"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, (&ValidationError{JSONPath: tt.jsonPath}).InstanceLocation())
+		})
+	}
+}
+
+func TestBasic(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(Output{Valid: true}, Basic(nil))
+	r.Equal(Output{Errors: []OutputUnit{{Error: "boom"}}}, Basic(errors.New("boom")))
+
+	err := Prefix(&ValidationError{
+		ErrType:                 "minimum",
+		JSONPath:                []interface{}{"count"},
+		Message:                 "must be greater than or equal to 3 but was 1",
+		KeywordLocation:         "/properties/count/minimum",
+		AbsoluteKeywordLocation: "https://example.com/bar.json#/properties/count/minimum",
+	}, "Bar", "bar", "/properties/bar/$ref")
+
+	b, jErr := json.Marshal(Basic(err))
+	r.NoError(jErr)
+	r.JSONEq(`{
+		"valid": false,
+		"errors": [
+			{
+				"keywordLocation": "/properties/bar/$ref/properties/count/minimum",
+				"absoluteKeywordLocation": "https://example.com/bar.json#/properties/count/minimum",
+				"instanceLocation": "/bar/count",
+				"error": "must be greater than or equal to 3 but was 1"
+			}
+		]
+	}`, string(b))
+}
@@ -0,0 +1,1301 @@
+// Package reflectschema derives JSON Schema documents from Go source: the reverse of jsonschema2go's generation.
+//
+// Types previously generated by jsonschema2go are recognized by their "is generated from" doc comments, which provide
+// their IDs, and by their Validate methods, from whose checks their required fields and validation keywords are
+// recovered. The oneOf and type unions and the tuples generated with their own JSON decoding are recognized by the
+// branches their methods switch over and the items they decode. Other types with their own decoding, checks which can't
+// be mapped back to a keyword and generated Validate methods returning errors in a form it doesn't recognize are
+// reported as errors rather than dropped. Values which may be null are only recognized as such when typed as
+// jsoptional.Nullable, since the pointers of the default pointer policy also hold absent values. Types which weren't
+// generated get IDs derived from their names and may declare validation keywords in a `jsonschema` struct tag, e.g.
+//
+//	Name string `json:"name" jsonschema:"required,minLength=1,pattern=^[a-z]+$"`
+//
+// The supported keywords are required, uniqueItems, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, minLength, maxLength, minItems, maxItems, format, enum (with values separated by |) and pattern, which
+// must come last as its value may contain commas.
+package reflectschema
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ns1/jsonschema2go/pkg/gen"
+)
+
+// Config controls how Go types are mapped to JSON Schema documents
+type Config struct {
+	// BaseURI is the URI against which the IDs of types without a generated ID comment are resolved. For example, with
+	// a BaseURI of https://example.com/schemas/ the type Bar gets the ID https://example.com/schemas/bar.json.
+	BaseURI string
+	// GoPath is the import path of the package. If set, it is recorded as the x-jsonschema2go gopath of types without
+	// a generated ID comment so that generating from the documents reproduces the same types.
+	GoPath string
+	// Initialisms are the initialisms the generator is configured with in addition to gen.CommonInitialisms, used to
+	// decide which fields need an alias to keep their names.
+	Initialisms []string
+}
+
+// Document is a JSON Schema document derived from a Go type
+type Document struct {
+	Name   string                 // the name of the Go type
+	ID     string                 // the ID of the schema
+	Schema map[string]interface{} // the schema, ready to be marshaled as JSON
+}
+
+// FromDir derives documents from the non-test Go files in the provided directory, which must contain a single package
+func FromDir(dir string, conf Config) ([]Document, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %q: %w", dir, err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %q but found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		var names []string
+		for name := range pkg.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			files = append(files, pkg.Files[name])
+		}
+	}
+	return FromFiles(files, conf)
+}
+
+// FromFiles derives a document for each exported type declared in the provided files, which must belong to a single
+// package and have been parsed with comments. Types generated from a subschema, i.e. whose ID has a fragment, are
+// inlined into the documents which use them rather than getting documents of their own. The types whose schemas can't
+// be derived are reported together in the returned error, alongside the documents of the others.
+func FromFiles(files []*ast.File, conf Config) ([]Document, error) {
+	r := &reflector{
+		conf:       conf,
+		namer:      gen.NewNamer(append(append([]string(nil), gen.CommonInitialisms...), conf.Initialisms...)),
+		types:      make(map[string]*typeDecl),
+		vars:       make(map[string]ast.Expr),
+		inProgress: make(map[string]bool),
+	}
+	for _, f := range files {
+		r.collect(f)
+	}
+	r.markHelpers()
+
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		docs     []Document
+		failures []string
+	)
+	for _, name := range names {
+		d := r.types[name]
+		if !r.topLevel(d) {
+			continue
+		}
+		id, err := r.id(d)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		r.inProgress[name] = true
+		schema, err := r.typeSchema(d)
+		delete(r.inProgress, name)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		schema["$id"] = id
+		if d.id == "" && conf.GoPath != "" {
+			config(schema)["gopath"] = conf.GoPath + "#" + name
+		}
+		docs = append(docs, Document{Name: name, ID: id, Schema: schema})
+	}
+	if len(failures) > 0 {
+		return docs, fmt.Errorf("unable to derive schemas for %s", strings.Join(failures, "; "))
+	}
+	return docs, nil
+}
+
+type typeDecl struct {
+	spec         *ast.TypeSpec
+	file         *ast.File
+	id           string         // the ID from a generated doc comment, if any
+	description  string         // the remainder of the doc comment
+	checks       []check        // the checks of a generated Validate method
+	unrecognized []ast.Expr     // the errors returned by the Validate method which aren't checks or of nested values
+	validator    *ast.BlockStmt // the body of the Validate method, if any
+	decoder      *ast.BlockStmt // the body of the UnmarshalJSON method if it implements its own JSON decoding
+	helper       bool           // whether the type is generated alongside a union, rather than from a schema
+}
+
+type reflector struct {
+	conf       Config
+	namer      *gen.Namer
+	types      map[string]*typeDecl
+	vars       map[string]ast.Expr // the values of package level variables, such as generated patterns and enums
+	inProgress map[string]bool
+}
+
+func (r *reflector) collect(f *ast.File) {
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.VAR {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.ValueSpec)
+					for i, name := range spec.Names {
+						if i < len(spec.Values) {
+							r.vars[name.Name] = spec.Values[i]
+						}
+					}
+				}
+				continue
+			}
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				doc := spec.Doc
+				if doc == nil && len(decl.Specs) == 1 {
+					doc = decl.Doc
+				}
+				d := r.decl(spec.Name.Name)
+				d.spec, d.file = spec, f
+				d.id, d.description = parseDoc(spec.Name.Name, doc)
+			}
+		case *ast.FuncDecl:
+			recv := receiver(decl)
+			if recv == "" {
+				continue
+			}
+			switch decl.Name.Name {
+			case "Validate":
+				d := r.decl(recv)
+				d.checks = append(d.checks, checks(decl.Body)...)
+				d.unrecognized = append(d.unrecognized, unrecognizedReturns(decl.Body)...)
+				d.validator = decl.Body
+			case "UnmarshalJSON":
+				if !decodesPlain(decl.Body) {
+					r.decl(recv).decoder = decl.Body
+					continue
+				}
+				// it only checks for required properties before decoding as usual
+				d := r.decl(recv)
+				d.checks = append(d.checks, checks(decl.Body)...)
+			}
+		}
+	}
+}
+
+// markHelpers marks the kinds and sealed interfaces of the values generated alongside unions
+func (r *reflector) markHelpers() {
+	for name, d := range r.types {
+		if d.spec == nil || d.decoder == nil {
+			continue
+		}
+		st, ok := d.spec.Type.(*ast.StructType)
+		if !ok || len(st.Fields.List) != 1 {
+			continue
+		}
+		if kind, ok := r.types[name+"Kind"]; ok {
+			kind.helper = true
+		}
+		if value, ok := st.Fields.List[0].Type.(*ast.Ident); ok {
+			if d, ok := r.types[value.Name]; ok {
+				d.helper = true
+			}
+		}
+	}
+}
+
+func (r *reflector) decl(name string) *typeDecl {
+	d, ok := r.types[name]
+	if !ok {
+		d = new(typeDecl)
+		r.types[name] = d
+	}
+	return d
+}
+
+func (r *reflector) topLevel(d *typeDecl) bool {
+	if d.spec == nil || !d.spec.Name.IsExported() || d.helper {
+		return false
+	}
+	if d.id == "" {
+		return true
+	}
+	u, err := url.Parse(d.id)
+	return err == nil && u.Fragment == ""
+}
+
+func (r *reflector) id(d *typeDecl) (string, error) {
+	if d.id != "" {
+		return d.id, nil
+	}
+	name := strings.ToLower(d.spec.Name.Name) + ".json"
+	if r.conf.BaseURI == "" {
+		return name, nil
+	}
+	base, err := url.Parse(r.conf.BaseURI)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URI %q: %w", r.conf.BaseURI, err)
+	}
+	return base.ResolveReference(&url.URL{Path: name}).String(), nil
+}
+
+func (r *reflector) typeSchema(d *typeDecl) (map[string]interface{}, error) {
+	var (
+		schema map[string]interface{}
+		err    error
+	)
+	var required []string
+	for _, c := range d.checks {
+		if c.errType == "required" && c.jsonName != "" {
+			required = append(required, c.jsonName)
+		}
+	}
+	if d.id != "" && len(d.unrecognized) > 0 {
+		// the method was generated differently, so its checks would otherwise be lost
+		return nil, fmt.Errorf("Validate returns %s, which isn't a recognized check", types.ExprString(d.unrecognized[0]))
+	}
+	switch t := d.spec.Type.(type) {
+	case *ast.StructType:
+		if d.decoder != nil {
+			// the JSON shape of a generated union can't be derived from its fields
+			schema, err = r.unionSchema(t, d)
+			break
+		}
+		schema, err = r.structSchema(t, d.file, required)
+	case *ast.ArrayType:
+		if d.decoder != nil {
+			schema, err = r.tupleSchema(t, d)
+			break
+		}
+		schema, err = r.exprSchema(t, d.file)
+	default:
+		if d.decoder != nil {
+			return nil, fmt.Errorf("%s implements its own JSON decoding", d.spec.Name.Name)
+		}
+		schema, err = r.exprSchema(t, d.file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range d.checks {
+		if c.errType == "required" || d.decoder != nil && unionCheck(c) {
+			continue
+		}
+		if err := r.applyCheck(schema, c); err != nil {
+			return nil, err
+		}
+	}
+	if d.description != "" {
+		schema["description"] = d.description
+	}
+	return schema, nil
+}
+
+func (r *reflector) structSchema(
+	st *ast.StructType,
+	file *ast.File,
+	required []string,
+) (map[string]interface{}, error) {
+	var (
+		properties = make(map[string]interface{})
+		allOf      []interface{}
+		aliases    = make(map[string]string)
+	)
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 { // embedded
+			s, err := r.exprSchema(field.Type, file)
+			if err != nil {
+				return nil, err
+			}
+			allOf = append(allOf, s)
+			continue
+		}
+
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			raw, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tag %s: %w", field.Tag.Value, err)
+			}
+			tag = reflect.StructTag(raw)
+		}
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			jsonName, omitEmpty := ident.Name, false
+			if jsonTag, ok := tag.Lookup("json"); ok {
+				if jsonTag == "-" {
+					continue
+				}
+				parts := strings.Split(jsonTag, ",")
+				if parts[0] != "" {
+					jsonName = parts[0]
+				}
+				for _, opt := range parts[1:] {
+					omitEmpty = omitEmpty || opt == "omitempty"
+				}
+			}
+
+			s, err := r.exprSchema(field.Type, file)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+			if doc := fieldDoc(field); doc != "" {
+				s["description"] = doc
+			}
+			isRequired, err := applyTag(s, tag.Get("jsonschema"))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+			if isRequired {
+				required = append(required, jsonName)
+			}
+			policy := pointerPolicy(field.Type, file, contains(required, jsonName))
+			switch isArray := s["type"] == "array"; {
+			case isArray && omitEmpty:
+				config(s)["omitEmptyArray"] = true
+			case policy != "":
+				// the policy reproduces the field's type, which is encoded as such
+				config(s)["pointers"] = policy
+			case !isArray && !omitEmpty:
+				config(s)["noOmitEmpty"] = true
+			}
+			if r.namer.JSONPropertyExported(jsonName) != ident.Name {
+				aliases[jsonName] = ident.Name
+			}
+			properties[jsonName] = s
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object"}
+	if len(properties) > 0 {
+		schema["properties"] = properties
+	}
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+	if required = dedupe(required); len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(aliases) > 0 {
+		config(schema)["fieldAliases"] = aliases
+	}
+	return schema, nil
+}
+
+func (r *reflector) exprSchema(expr ast.Expr, file *ast.File) (map[string]interface{}, error) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.exprSchema(t.X, file)
+	case *ast.ParenExpr:
+		return r.exprSchema(t.X, file)
+	case *ast.IndexExpr:
+		sel, ok := t.X.(*ast.SelectorExpr)
+		if !ok {
+			return nil, errors.New("generic types are unsupported")
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || importPath(file, pkg.Name) != jsoptionalPath {
+			return nil, errors.New("generic types are unsupported")
+		}
+		schema, err := r.exprSchema(t.Index, file)
+		if err != nil {
+			return nil, err
+		}
+		switch sel.Sel.Name {
+		case "Optional":
+			return schema, nil
+		case "Nullable":
+			// null is removed again by notNull if the generated Validate method rejects it
+			return nullable(schema), nil
+		}
+		return nil, fmt.Errorf("unsupported type jsoptional.%s", sel.Sel.Name)
+	case *ast.Ident:
+		if typ, ok := builtins[t.Name]; ok {
+			if typ == "" {
+				return make(map[string]interface{}), nil
+			}
+			return map[string]interface{}{"type": typ}, nil
+		}
+		return r.namedSchema(t.Name)
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return nil, errors.New("non-empty interfaces are unsupported")
+		}
+		return make(map[string]interface{}), nil
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" && t.Len == nil {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := r.exprSchema(t.Elt, file)
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]interface{}{"type": "array", "items": items}
+		if t.Len != nil {
+			lit, ok := t.Len.(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT {
+				return nil, errors.New("array lengths must be integer literals")
+			}
+			n, err := strconv.ParseUint(lit.Value, 0, 64)
+			if err != nil {
+				return nil, err
+			}
+			schema["minItems"], schema["maxItems"] = n, n
+		}
+		return schema, nil
+	case *ast.MapType:
+		if key, ok := t.Key.(*ast.Ident); !ok || key.Name != "string" {
+			return nil, errors.New("only maps with string keys are supported")
+		}
+		values, err := r.exprSchema(t.Value, file)
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]interface{}{"type": "object"}
+		if len(values) == 0 {
+			schema["additionalProperties"] = true
+		} else {
+			schema["additionalProperties"] = values
+		}
+		return schema, nil
+	case *ast.StructType:
+		return r.structSchema(t, file, nil)
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %T", t.X)
+		}
+		goPath := importPath(file, pkg.Name)
+		if goPath == "" {
+			return nil, fmt.Errorf("unknown package %q", pkg.Name)
+		}
+		switch goPath + "." + t.Sel.Name {
+		case "encoding/json.RawMessage":
+			return map[string]interface{}{"x-jsonschema2go": map[string]interface{}{"rawMessage": true}}, nil
+		case "time.Time":
+			return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+		}
+		// types from other packages are referenced rather than generated
+		return map[string]interface{}{
+			"x-jsonschema2go": map[string]interface{}{"gopath": goPath + "#" + t.Sel.Name, "exclude": true},
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", expr)
+}
+
+func (r *reflector) namedSchema(name string) (map[string]interface{}, error) {
+	d, ok := r.types[name]
+	if !ok || d.spec == nil {
+		return nil, fmt.Errorf("unknown type %q", name)
+	}
+	if r.topLevel(d) {
+		id, err := r.id(d)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"$ref": id}, nil
+	}
+	if r.inProgress[name] {
+		return nil, fmt.Errorf("recursive type %q must have its own ID", name)
+	}
+	r.inProgress[name] = true
+	defer delete(r.inProgress, name)
+	return r.typeSchema(d)
+}
+
+// unionSchema rebuilds the oneOf or type union of a generated union from the types its Validate method switches over
+func (r *reflector) unionSchema(st *ast.StructType, d *typeDecl) (map[string]interface{}, error) {
+	branches, keyword := unionBranches(d.validator)
+	if len(st.Fields.List) != 1 || len(st.Fields.List[0].Names) != 1 || st.Fields.List[0].Names[0].Name != "Value" ||
+		len(branches) == 0 {
+		return nil, fmt.Errorf("%s implements its own JSON decoding", d.spec.Name.Name)
+	}
+	typeUnion := keyword == "type"
+
+	var (
+		schema = make(map[string]interface{})
+		oneOf  []interface{}
+		types  []interface{}
+	)
+	for i, b := range branches {
+		if ident, ok := b.(*ast.Ident); ok && ident.Name == "nil" {
+			oneOf = append(oneOf, map[string]interface{}{"type": "null"})
+			types = append(types, "null")
+			continue
+		}
+		s, err := r.exprSchema(b, d.file)
+		if err != nil {
+			return nil, fmt.Errorf("branch %d: %w", i, err)
+		}
+		oneOf = append(oneOf, s)
+		if !typeUnion {
+			continue
+		}
+		if _, ok := s["type"].(string); !ok {
+			return nil, fmt.Errorf("unable to recover the type of branch %d", i)
+		}
+		// the branches of a type union carry the keywords of the schema itself
+		for k, v := range s {
+			switch k {
+			case "type":
+				types = append(types, v)
+			case "description":
+			default:
+				schema[k] = v
+			}
+		}
+	}
+	if !typeUnion {
+		return map[string]interface{}{"oneOf": oneOf}, nil
+	}
+	schema["type"] = types
+	return schema, nil
+}
+
+// tupleSchema rebuilds the items of a generated tuple from the types its UnmarshalJSON method decodes them as
+func (r *reflector) tupleSchema(t *ast.ArrayType, d *typeDecl) (map[string]interface{}, error) {
+	var types []ast.Expr
+	ast.Inspect(d.decoder, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.ValueSpec); ok && len(spec.Names) == 1 && spec.Names[0].Name == "item" {
+			types = append(types, spec.Type)
+		}
+		return true
+	})
+	if t.Len == nil || len(types) == 0 {
+		return nil, fmt.Errorf("%s implements its own JSON decoding", d.spec.Name.Name)
+	}
+
+	var items []interface{}
+	for i, typ := range types {
+		s, err := r.exprSchema(typ, d.file)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		items = append(items, s)
+	}
+	return map[string]interface{}{"type": "array", "items": items}, nil
+}
+
+// builtins maps Go's predeclared types to JSON types; those which may hold any value map to the empty string
+var builtins = map[string]string{
+	"bool":    "boolean",
+	"string":  "string",
+	"int":     "integer",
+	"int8":    "integer",
+	"int16":   "integer",
+	"int32":   "integer",
+	"int64":   "integer",
+	"uint":    "integer",
+	"uint8":   "integer",
+	"uint16":  "integer",
+	"uint32":  "integer",
+	"uint64":  "integer",
+	"byte":    "integer",
+	"rune":    "integer",
+	"float32": "number",
+	"float64": "number",
+	"any":     "",
+}
+
+// applyTag sets the keywords of a `jsonschema` struct tag on the schema and returns whether the field is required
+func applyTag(schema map[string]interface{}, tag string) (required bool, _ error) {
+	for tag != "" {
+		var opt string
+		if strings.HasPrefix(tag, "pattern=") {
+			opt, tag = tag, ""
+		} else {
+			parts := strings.SplitN(tag, ",", 2)
+			opt, tag = parts[0], ""
+			if len(parts) == 2 {
+				tag = parts[1]
+			}
+		}
+
+		kv := strings.SplitN(opt, "=", 2)
+		key, val := kv[0], ""
+		if len(kv) == 2 {
+			val = kv[1]
+		}
+		switch key {
+		case "required":
+			required = true
+		case "uniqueItems":
+			schema[key] = true
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf":
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s %q: %w", key, val, err)
+			}
+			schema[key] = f
+		case "minLength", "maxLength", "minItems", "maxItems":
+			n, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s %q: %w", key, val, err)
+			}
+			schema[key] = n
+		case "format", "pattern":
+			schema[key] = val
+		case "enum":
+			var enum []interface{}
+			for _, v := range strings.Split(val, "|") {
+				switch schema["type"] {
+				case "integer", "number":
+					f, err := strconv.ParseFloat(v, 64)
+					if err != nil {
+						return false, fmt.Errorf("invalid enum value %q: %w", v, err)
+					}
+					enum = append(enum, f)
+				default:
+					enum = append(enum, v)
+				}
+			}
+			schema[key] = enum
+		case "":
+		default:
+			return false, fmt.Errorf("unknown jsonschema tag option %q", key)
+		}
+	}
+	return required, nil
+}
+
+// parseDoc splits a type's doc comment into the ID recorded by jsonschema2go, if any, and the remaining description
+func parseDoc(name string, doc *ast.CommentGroup) (id, description string) {
+	if doc == nil {
+		return "", ""
+	}
+	prefix := name + " is generated from "
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(doc.Text()), "\n") {
+		if strings.HasPrefix(line, prefix) && id == "" {
+			id = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return id, strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func fieldDoc(field *ast.Field) string {
+	if field.Doc != nil {
+		return strings.TrimSpace(field.Doc.Text())
+	}
+	if field.Comment != nil {
+		return strings.TrimSpace(field.Comment.Text())
+	}
+	return ""
+}
+
+// check is a check of a generated Validate method which returns a validation error
+type check struct {
+	errType  string
+	location string   // the keyword location of the error, if recorded
+	jsonName string   // the JSON property the error is reported at, if any
+	cond     ast.Expr // the condition under which the error is returned
+	message  ast.Expr
+}
+
+// checks returns the checks of a generated Validate method
+func checks(body *ast.BlockStmt) (checks []check) {
+	if body == nil {
+		return nil
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		ifStmt, ok := n.(*ast.IfStmt)
+		if !ok {
+			return true
+		}
+		for _, stmt := range ifStmt.Body.List {
+			ret, ok := stmt.(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+			result := ret.Results[0]
+			if addr, ok := result.(*ast.UnaryExpr); ok && addr.Op == token.AND {
+				result = addr.X
+			}
+			lit, ok := result.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			c := check{cond: ifStmt.Cond}
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch strings.ToLower(key.Name) {
+				case "errtype":
+					c.errType = stringLit(kv.Value)
+				case "keywordlocation":
+					c.location = stringLit(kv.Value)
+				case "message":
+					c.message = kv.Value
+				case "jsonpath":
+					if path, ok := kv.Value.(*ast.CompositeLit); ok && len(path.Elts) == 1 {
+						c.jsonName = stringLit(path.Elts[0])
+					}
+				}
+			}
+			if c.errType != "" {
+				checks = append(checks, c)
+			}
+		}
+		return true
+	})
+	return checks
+}
+
+// unrecognizedReturns returns the errors returned by a Validate method which are neither the validation errors of its
+// checks nor the errors of nested values, as returned by a Validate method generated in a form checks doesn't expect
+func unrecognizedReturns(body *ast.BlockStmt) (results []ast.Expr) {
+	if body == nil {
+		return nil
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return true
+		}
+		switch result := ret.Results[0].(type) {
+		case *ast.Ident:
+			if result.Name == "nil" || result.Name == "err" {
+				return true
+			}
+		case *ast.CallExpr:
+			// the error of a nested value, prefixed with its location
+			for _, arg := range result.Args {
+				if ident, ok := arg.(*ast.Ident); ok && ident.Name == "err" {
+					return true
+				}
+			}
+		default:
+			if errType(result) != "" {
+				return true
+			}
+		}
+		results = append(results, ret.Results[0])
+		return true
+	})
+	return results
+}
+
+// decodesPlain returns whether an UnmarshalJSON method decodes into a plain version of its type, lacking its methods,
+// as generated to check for required properties first
+func decodesPlain(body *ast.BlockStmt) bool {
+	if body == nil {
+		return false
+	}
+	for _, stmt := range body.List {
+		decl, ok := stmt.(*ast.DeclStmt)
+		if !ok {
+			continue
+		}
+		if gen, ok := decl.Decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE && len(gen.Specs) == 1 {
+			if spec, ok := gen.Specs[0].(*ast.TypeSpec); ok && spec.Name.Name == "plain" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unionBranches returns the types switched over by the first type switch of a generated union's Validate method, in
+// the order of the union's subschemas, along with the keyword of the error returned for other types, if any
+func unionBranches(body *ast.BlockStmt) (branches []ast.Expr, keyword string) {
+	if body == nil {
+		return nil, ""
+	}
+	for _, stmt := range body.List {
+		sw, ok := stmt.(*ast.TypeSwitchStmt)
+		if !ok {
+			continue
+		}
+		var null ast.Expr
+		for _, clause := range sw.Body.List {
+			clause := clause.(*ast.CaseClause)
+			if clause.List == nil {
+				keyword = errType(clause)
+			}
+			for _, b := range clause.List {
+				if ident, ok := b.(*ast.Ident); ok && ident.Name == "nil" {
+					// null is conventionally listed last
+					null = b
+					continue
+				}
+				branches = append(branches, b)
+			}
+		}
+		if null != nil {
+			branches = append(branches, null)
+		}
+		return placeBranches(body, branches), keyword
+	}
+	return nil, ""
+}
+
+// errType returns the type of the validation error constructed within the node, if any
+func errType(node ast.Node) (errType string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if kv, ok := n.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && strings.EqualFold(key.Name, "errType") {
+				errType = stringLit(kv.Value)
+			}
+		}
+		return errType == ""
+	})
+	return errType
+}
+
+// placeBranches orders the branches of a generated oneOf by the indexes recorded in the keyword locations of the
+// checks of their values, which are listed by JSON type rather than in the order of the subschemas. The others fill
+// the remaining places in turn.
+func placeBranches(body *ast.BlockStmt, branches []ast.Expr) []ast.Expr {
+	indexes := make(map[string]int)
+	record := func(typ ast.Expr, scope ast.Node) {
+		ast.Inspect(scope, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			tokens := strings.Split(stringLit(lit), "/")
+			if len(tokens) > 2 && tokens[0] == "" && tokens[1] == "oneOf" {
+				if i, err := strconv.Atoi(tokens[2]); err == nil {
+					indexes[types.ExprString(typ)] = i
+				}
+			}
+			return true
+		})
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			// e.g. if v, ok := m.Value.(string); ok {
+			if assign, ok := n.Init.(*ast.AssignStmt); ok && len(assign.Rhs) == 1 {
+				if assert, ok := assign.Rhs[0].(*ast.TypeAssertExpr); ok && assert.Type != nil {
+					record(assert.Type, n.Body)
+				}
+			}
+		case *ast.CaseClause:
+			if len(n.List) == 1 {
+				record(n.List[0], &ast.BlockStmt{List: n.Body})
+			}
+		}
+		return true
+	})
+
+	placed := make([]ast.Expr, len(branches))
+	var rest []ast.Expr
+	for _, b := range branches {
+		i, ok := indexes[types.ExprString(b)]
+		if !ok || i >= len(placed) || placed[i] != nil {
+			rest = append(rest, b)
+			continue
+		}
+		placed[i] = b
+	}
+	for i := range placed {
+		if placed[i] == nil {
+			placed[i], rest = rest[0], rest[1:]
+		}
+	}
+	return placed
+}
+
+// unionCheck returns whether a check of a type with its own decoding is of the type of its value as a whole, which is
+// recovered from the type's shape instead
+func unionCheck(c check) bool {
+	return c.errType == "oneOf" || (c.errType == "type" && strings.Count(c.location, "/") <= 1)
+}
+
+// checkKeywords maps the error types of generated checks to the keywords they validate
+var checkKeywords = map[string]string{
+	"type":             "type",
+	"pattern":          "pattern",
+	"enum":             "enum",
+	"length":           "minLength",
+	"minLength":        "minLength",
+	"maxLength":        "maxLength",
+	"contentMediaType": "contentMediaType",
+	"multipleOf":       "multipleOf",
+	"minimum":          "minimum",
+	"maximum":          "maximum",
+	"minimumExclusive": "exclusiveMinimum",
+	"maximumExclusive": "exclusiveMaximum",
+	"minItems":         "minItems",
+	"maxItems":         "maxItems",
+	"uniqueItems":      "uniqueItems",
+	"min_properties":   "minProperties",
+	"max_properties":   "maxProperties",
+}
+
+// applyCheck sets the keyword validated by a generated check on the subschema it applies to
+func (r *reflector) applyCheck(schema map[string]interface{}, c check) error {
+	keyword, ok := checkKeywords[c.errType]
+	if !ok {
+		return fmt.Errorf("unable to recover the keyword of %s validation", c.errType)
+	}
+	location := c.location
+	if location == "" {
+		if c.jsonName == "" {
+			return fmt.Errorf("unable to recover the location of %s validation", c.errType)
+		}
+		// generated before keyword locations were recorded
+		location = "/properties/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(c.jsonName) + "/" + keyword
+	}
+
+	target := schema
+	tokens := strings.Split(strings.TrimPrefix(location, "/"), "/")
+	for i := 0; i < len(tokens)-1; i++ {
+		tok := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[i])
+		if tok == "$ref" {
+			// the referenced schema is inlined as the field's type
+			continue
+		}
+		next := target[tok]
+		if subschemas, ok := next.([]interface{}); ok && i+1 < len(tokens)-1 {
+			// the branches of a oneOf and the items of a tuple are indexed
+			i++
+			if n, err := strconv.Atoi(tokens[i]); err == nil && n >= 0 && n < len(subschemas) {
+				next = subschemas[n]
+			}
+		}
+		var ok bool
+		if target, ok = next.(map[string]interface{}); !ok {
+			return fmt.Errorf("unable to recover %s validation at %s", c.errType, location)
+		}
+	}
+	if _, ok := target["$ref"]; ok {
+		return fmt.Errorf("unable to recover %s validation of a reference at %s", c.errType, location)
+	}
+
+	test := c.cond
+	for {
+		// skip guards, such as whether the value is set
+		and, ok := test.(*ast.BinaryExpr)
+		if !ok || and.Op != token.LAND {
+			break
+		}
+		test = and.Y
+	}
+	var (
+		val interface{}
+		err error
+	)
+	switch c.errType {
+	case "type":
+		notNull(target)
+		return nil
+	case "uniqueItems":
+		val = true
+	case "pattern":
+		val, err = r.pattern(test)
+	case "enum":
+		val, err = r.enum(test)
+	case "contentMediaType":
+		val, err = mediaType(c.message)
+		if conf, ok := target["x-jsonschema2go"].(map[string]interface{}); ok && conf["rawMessage"] == true {
+			// a json.RawMessage holding the document embedded in a string
+			delete(conf, "rawMessage")
+			if len(conf) == 0 {
+				delete(target, "x-jsonschema2go")
+			}
+			target["type"] = "string"
+		}
+	case "multipleOf", "minimum", "maximum", "minimumExclusive", "maximumExclusive":
+		val, err = number(limit(test))
+	case "length":
+		var n uint64
+		if n, err = count(limit(test)); err == nil {
+			target["maxLength"] = n
+		}
+		val = n
+	default:
+		val, err = count(limit(test))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to recover %s validation at %s: %w", c.errType, location, err)
+	}
+	target[keyword] = val
+	return nil
+}
+
+// pattern returns the regular expression matched by a generated pattern check
+func (r *reflector) pattern(test ast.Expr) (string, error) {
+	if not, ok := test.(*ast.UnaryExpr); ok && not.Op == token.NOT {
+		if call, ok := not.X.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					if compile, ok := r.vars[ident.Name].(*ast.CallExpr); ok && len(compile.Args) == 1 {
+						if lit, ok := compile.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							return strconv.Unquote(lit.Value)
+						}
+					}
+				}
+			}
+		}
+	}
+	return "", errors.New("unrecognized check")
+}
+
+// enum returns the values accepted by a generated enum check
+func (r *reflector) enum(test ast.Expr) ([]interface{}, error) {
+	if not, ok := test.(*ast.UnaryExpr); ok && not.Op == token.NOT {
+		if index, ok := not.X.(*ast.IndexExpr); ok {
+			if ident, ok := index.X.(*ast.Ident); ok {
+				if m, ok := r.vars[ident.Name].(*ast.CompositeLit); ok {
+					var enum []interface{}
+					for _, elt := range m.Elts {
+						kv, ok := elt.(*ast.KeyValueExpr)
+						if !ok {
+							return nil, errors.New("unrecognized enum")
+						}
+						if lit, ok := kv.Key.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							s, err := strconv.Unquote(lit.Value)
+							if err != nil {
+								return nil, err
+							}
+							enum = append(enum, s)
+							continue
+						}
+						f, err := number(kv.Key)
+						if err != nil {
+							return nil, err
+						}
+						enum = append(enum, f)
+					}
+					return enum, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("unrecognized check")
+}
+
+// limit returns the operand a generated comparison compares against, e.g. 3 in len(s) < 3 or math.Mod(f, 3) != 0
+func limit(test ast.Expr) ast.Expr {
+	cmp, ok := test.(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+	if cmp.Op == token.NEQ {
+		switch x := cmp.X.(type) {
+		case *ast.BinaryExpr:
+			if x.Op == token.REM {
+				return x.Y
+			}
+		case *ast.CallExpr:
+			if len(x.Args) == 2 {
+				return x.Args[1]
+			}
+		}
+	}
+	return cmp.Y
+}
+
+func number(expr ast.Expr) (float64, error) {
+	sign := 1.0
+	if neg, ok := expr.(*ast.UnaryExpr); ok && neg.Op == token.SUB {
+		sign, expr = -1, neg.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+		return 0, errors.New("expected a number")
+	}
+	f, err := strconv.ParseFloat(lit.Value, 64)
+	return sign * f, err
+}
+
+func count(expr ast.Expr) (uint64, error) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, errors.New("expected a count")
+	}
+	return strconv.ParseUint(lit.Value, 10, 64)
+}
+
+// mediaType returns the media type named by the message of a generated contentMediaType check
+func mediaType(message ast.Expr) (string, error) {
+	if call, ok := message.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		message = call.Args[0]
+	}
+	msg := stringLit(message)
+	const prefix, suffix = "must contain a valid ", " document"
+	if !strings.HasPrefix(msg, prefix) || !strings.HasSuffix(msg, suffix) {
+		return "", errors.New("unrecognized message")
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(msg, prefix), suffix), "%%", "%"), nil
+}
+
+// nullable returns the schema extended to also accept null
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	switch typ := schema["type"].(type) {
+	case string:
+		schema["type"] = []interface{}{typ, "null"}
+		return schema
+	case []interface{}:
+		// a union of types, which may already accept null
+		for _, t := range typ {
+			if t == "null" {
+				return schema
+			}
+		}
+		schema["type"] = append(typ, "null")
+		return schema
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
+
+// notNull reverts nullable, for a value whose generated Validate method rejects null
+func notNull(schema map[string]interface{}) {
+	if types, ok := schema["type"].([]interface{}); ok && len(types) == 2 && types[1] == "null" {
+		schema["type"] = types[0]
+		return
+	}
+	anyOf, ok := schema["anyOf"].([]interface{})
+	if !ok || len(anyOf) != 2 {
+		return
+	}
+	inner, ok := anyOf[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(schema, "anyOf")
+	for k, v := range inner {
+		schema[k] = v
+	}
+}
+
+func stringLit(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	s, _ := strconv.Unquote(lit.Value)
+	return s
+}
+
+// receiver returns the name of the type of a method's receiver, or an empty string for functions
+func receiver(f *ast.FuncDecl) string {
+	if f.Recv == nil || len(f.Recv.List) != 1 {
+		return ""
+	}
+	typ := f.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// importPath returns the import path of the package referred to by name within the file
+func importPath(file *ast.File, name string) string {
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return p
+			}
+			continue
+		}
+		if p[strings.LastIndex(p, "/")+1:] == name {
+			return p
+		}
+	}
+	return ""
+}
+
+const jsoptionalPath = "github.com/ns1/jsonschema2go/pkg/jsoptional"
+
+// pointerPolicy returns the pointer policy under which jsonschema2go types a field as the provided type, or an empty
+// string if it isn't typed as such under the default policy
+func pointerPolicy(expr ast.Expr, file *ast.File, required bool) gen.PointerPolicy {
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		sel, ok := t.X.(*ast.SelectorExpr)
+		if !ok {
+			return ""
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || importPath(file, pkg.Name) != jsoptionalPath {
+			return ""
+		}
+		switch sel.Sel.Name {
+		case "Optional":
+			return gen.PointersGeneric
+		case "Nullable":
+			return gen.PointersNullable
+		}
+	case *ast.Ident:
+		if builtins[t.Name] != "" && required {
+			// required primitives are values under the optional policy
+			return gen.PointersOptional
+		}
+	}
+	return ""
+}
+
+// config returns the x-jsonschema2go extension of the schema, adding it if absent
+func config(schema map[string]interface{}) map[string]interface{} {
+	c, ok := schema["x-jsonschema2go"].(map[string]interface{})
+	if !ok {
+		c = make(map[string]interface{})
+		schema["x-jsonschema2go"] = c
+	}
+	return c
+}
+
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(vals []string) (deduped []string) {
+	seen := make(map[string]bool, len(vals))
+	for _, v := range vals {
+		if !seen[v] {
+			seen[v] = true
+			deduped = append(deduped, v)
+		}
+	}
+	sort.Strings(deduped)
+	return
+}
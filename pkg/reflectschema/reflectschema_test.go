@@ -0,0 +1,662 @@
+package reflectschema
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		conf    Config
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "hand written",
+			src: `package foo
+
+import "encoding/json"
+
+// Bar holds some info
+type Bar struct {
+	// Name is the name of the bar
+	Name    string          ` + "`" + `json:"name" jsonschema:"required,minLength=1,pattern=^[a-z,]+$"` + "`" + `
+	Count   *int64          ` + "`" + `json:"count,omitempty" jsonschema:"minimum=1,enum=1|2|3"` + "`" + `
+	Tags    []string        ` + "`" + `json:"tags,omitempty" jsonschema:"uniqueItems"` + "`" + `
+	Data    []byte          ` + "`" + `json:"data,omitempty"` + "`" + `
+	Raw     json.RawMessage ` + "`" + `json:"raw,omitempty"` + "`" + `
+	Baz     *Baz            ` + "`" + `json:"baz,omitempty"` + "`" + `
+	ID      *string         ` + "`" + `json:"identifier,omitempty"` + "`" + `
+	Ignored string          ` + "`" + `json:"-"` + "`" + `
+	private string
+}
+
+type Baz map[string]interface{}
+`,
+			conf: Config{BaseURI: "https://example.com/schemas/", GoPath: "example.com/foo"},
+			want: map[string]string{
+				"Bar": `{
+					"$id": "https://example.com/schemas/bar.json",
+					"description": "Bar holds some info",
+					"type": "object",
+					"properties": {
+						"name": {
+							"type": "string",
+							"description": "Name is the name of the bar",
+							"minLength": 1,
+							"pattern": "^[a-z,]+$",
+							"x-jsonschema2go": {"pointers": "optional"}
+						},
+						"count": {"type": "integer", "minimum": 1, "enum": [1, 2, 3]},
+						"tags": {
+							"type": "array",
+							"items": {"type": "string"},
+							"uniqueItems": true,
+							"x-jsonschema2go": {"omitEmptyArray": true}
+						},
+						"data": {"type": "string", "contentEncoding": "base64"},
+						"raw": {"x-jsonschema2go": {"rawMessage": true}},
+						"baz": {"$ref": "https://example.com/schemas/baz.json"},
+						"identifier": {"type": "string"}
+					},
+					"required": ["name"],
+					"x-jsonschema2go": {
+						"gopath": "example.com/foo#Bar",
+						"fieldAliases": {"identifier": "ID"}
+					}
+				}`,
+				"Baz": `{
+					"$id": "https://example.com/schemas/baz.json",
+					"type": "object",
+					"additionalProperties": true,
+					"x-jsonschema2go": {"gopath": "example.com/foo#Baz"}
+				}`,
+			},
+		},
+		{
+			name: "generated",
+			src: `package foo
+
+import (
+	"github.com/ns1/jsonschema2go/pkg/jsoptional"
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
+	other "example.com/other"
+	"regexp"
+)
+
+// Bar is generated from https://example.com/foo/bar.json
+// Bar contains some info
+type Bar struct {
+	BarAllOf0
+	Items BarItems                    ` + "`" + `json:"items"` + "`" + `
+	Other *other.Type                 ` + "`" + `json:"other,omitempty"` + "`" + `
+	Code  jsoptional.Optional[string] ` + "`" + `json:"code,omitempty"` + "`" + `
+	Ratio *float64                    ` + "`" + `json:"ratio,omitempty"` + "`" + `
+}
+
+var (
+	barCodePattern = regexp.MustCompile(` + "`" + `^[A-Z]+$` + "`" + `)
+	barCodeEnum    = map[string]bool{"AB": true, "CD": true}
+)
+
+func (m *Bar) Validate() error {
+	if m.Items == nil {
+		return &jsvalidate.ValidationError{
+			ErrType:  "required",
+			Message:  "field required",
+			Path:     []interface{}{"Items"},
+			JSONPath: []interface{}{"items"},
+		}
+	}
+	if m.Code.Set && !barCodePattern.MatchString(m.Code.Value) {
+		return &jsvalidate.ValidationError{
+			ErrType:         "pattern",
+			JSONPath:        []interface{}{"code"},
+			KeywordLocation: "/properties/code/pattern",
+		}
+	}
+	if m.Code.Set && len(m.Code.Value) != 2 {
+		return &jsvalidate.ValidationError{
+			ErrType:         "length",
+			JSONPath:        []interface{}{"code"},
+			KeywordLocation: "/properties/code/minLength",
+		}
+	}
+	if m.Code.Set && !barCodeEnum[m.Code.Value] {
+		return &jsvalidate.ValidationError{
+			ErrType:         "enum",
+			JSONPath:        []interface{}{"code"},
+			KeywordLocation: "/properties/code/enum",
+		}
+	}
+	if m.Ratio != nil && *m.Ratio <= -1.5 {
+		return &jsvalidate.ValidationError{
+			ErrType:  "minimumExclusive",
+			JSONPath: []interface{}{"ratio"},
+		}
+	}
+	if m.Ratio != nil && math.Mod(*m.Ratio, 0.5) != 0 {
+		return &jsvalidate.ValidationError{
+			ErrType:         "multipleOf",
+			JSONPath:        []interface{}{"ratio"},
+			KeywordLocation: "/properties/ratio/multipleOf",
+		}
+	}
+	return nil
+}
+
+// BarAllOf0 is generated from https://example.com/foo/bar.json#/allOf/0
+type BarAllOf0 struct {
+	Count *int64 ` + "`" + `json:"count,omitempty"` + "`" + `
+}
+
+// BarItems is generated from https://example.com/foo/bar.json#/properties/items
+type BarItems []*Baz
+
+func (m BarItems) Validate() error {
+	if len(m) > 3 {
+		return &jsvalidate.ValidationError{
+			ErrType:         "maxItems",
+			KeywordLocation: "/maxItems",
+		}
+	}
+	return nil
+}
+
+// Baz is generated from https://example.com/foo/baz.json
+type Baz map[string]string
+
+func (m Baz) Validate() error {
+	if len(m) < 1 {
+		return &validationError{
+			errType:         "min_properties",
+			keywordLocation: "/minProperties",
+		}
+	}
+	for _, k := range keys {
+		if !bazPattern.MatchString(m[k]) {
+			return &validationError{
+				errType:         "pattern",
+				keywordLocation: "/additionalProperties/pattern",
+			}
+		}
+	}
+	return nil
+}
+
+var bazPattern = regexp.MustCompile("^a")
+`,
+			want: map[string]string{
+				"Bar": `{
+					"$id": "https://example.com/foo/bar.json",
+					"description": "Bar contains some info",
+					"type": "object",
+					"allOf": [{"type": "object", "properties": {"count": {"type": "integer"}}}],
+					"properties": {
+						"items": {
+							"type": "array",
+							"items": {"$ref": "https://example.com/foo/baz.json"},
+							"maxItems": 3
+						},
+						"other": {"x-jsonschema2go": {"gopath": "example.com/other#Type", "exclude": true}},
+						"code": {
+							"type": "string",
+							"pattern": "^[A-Z]+$",
+							"minLength": 2,
+							"maxLength": 2,
+							"enum": ["AB", "CD"],
+							"x-jsonschema2go": {"pointers": "generic"}
+						},
+						"ratio": {"type": "number", "exclusiveMinimum": -1.5, "multipleOf": 0.5}
+					},
+					"required": ["items"]
+				}`,
+				"Baz": `{
+					"$id": "https://example.com/foo/baz.json",
+					"type": "object",
+					"additionalProperties": {"type": "string", "pattern": "^a"},
+					"minProperties": 1
+				}`,
+			},
+		},
+		{
+			name: "custom decoding",
+			src: `package foo
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	Value interface{}
+}
+
+func (m *Bar) UnmarshalJSON(data []byte) error {
+	return nil
+}
+
+// Baz is generated from https://example.com/foo/baz.json
+type Baz struct {
+	Name *string ` + "`" + `json:"name,omitempty"` + "`" + `
+}
+`,
+			want: map[string]string{
+				"Baz": `{
+					"$id": "https://example.com/foo/baz.json",
+					"type": "object",
+					"properties": {"name": {"type": "string"}}
+				}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unrecoverable check",
+			src: `package foo
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	Value interface{} ` + "`" + `json:"value,omitempty"` + "`" + `
+}
+
+func (m *Bar) Validate() error {
+	if v, ok := m.Value.(string); ok {
+		if len(v) < 3 {
+			return &validationError{errType: "minLength"}
+		}
+	}
+	return nil
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "decoding declared before validation",
+			src: `package foo
+
+import (
+	"encoding/json"
+
+	"github.com/ns1/jsonschema2go/pkg/jsvalidate"
+)
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	Name  string  ` + "`" + `json:"name"` + "`" + `
+	Label *string ` + "`" + `json:"label,omitempty"` + "`" + `
+}
+
+func (m *Bar) UnmarshalJSON(data []byte) error {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	if _, ok := keys["name"]; !ok {
+		return &jsvalidate.ValidationError{
+			ErrType:         "required",
+			JSONPath:        []interface{}{"name"},
+			KeywordLocation: "/required",
+		}
+	}
+	type plain Bar
+	return json.Unmarshal(data, (*plain)(m))
+}
+
+func (m *Bar) Validate() error {
+	if m.Label != nil && len(*m.Label) > 3 {
+		return &jsvalidate.ValidationError{
+			ErrType:         "maxLength",
+			JSONPath:        []interface{}{"label"},
+			KeywordLocation: "/properties/label/maxLength",
+		}
+	}
+	return nil
+}
+`,
+			want: map[string]string{
+				"Bar": `{
+					"$id": "https://example.com/foo/bar.json",
+					"type": "object",
+					"properties": {
+						"name": {"type": "string", "x-jsonschema2go": {"pointers": "optional"}},
+						"label": {"type": "string", "maxLength": 3}
+					},
+					"required": ["name"]
+				}`,
+			},
+		},
+		{
+			name: "unrecognized return",
+			src: `package foo
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	Name *string ` + "`" + `json:"name,omitempty"` + "`" + `
+}
+
+// Validate returns an error if this value is invalid according to rules defined in https://example.com/foo/bar.json
+func (m *Bar) Validate() error {
+	if m.Name != nil && len(*m.Name) < 1 {
+		return newValidationError("minLength", "/properties/name/minLength")
+	}
+	return nil
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "recursive without ID",
+			src: `package foo
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	Next *BarNext ` + "`" + `json:"next,omitempty"` + "`" + `
+}
+
+// BarNext is generated from https://example.com/foo/bar.json#/properties/next
+type BarNext struct {
+	Next *BarNext ` + "`" + `json:"next,omitempty"` + "`" + `
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid base URI",
+			src: `package foo
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	Name string ` + "`" + `json:"name"` + "`" + `
+}
+
+type Baz struct {
+	Name string ` + "`" + `json:"name"` + "`" + `
+}
+`,
+			conf: Config{BaseURI: "%zz"},
+			want: map[string]string{
+				"Bar": `{
+					"$id": "https://example.com/foo/bar.json",
+					"type": "object",
+					"properties": {"name": {"type": "string", "x-jsonschema2go": {"noOmitEmpty": true}}}
+				}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional initialisms",
+			src: `package foo
+
+// Bar is generated from https://example.com/foo/bar.json
+type Bar struct {
+	SKU string ` + "`" + `json:"sku"` + "`" + `
+	ARN string ` + "`" + `json:"arn"` + "`" + `
+}
+`,
+			conf: Config{Initialisms: []string{"SKU"}},
+			want: map[string]string{
+				"Bar": `{
+					"$id": "https://example.com/foo/bar.json",
+					"type": "object",
+					"properties": {
+						"sku": {"type": "string", "x-jsonschema2go": {"noOmitEmpty": true}},
+						"arn": {"type": "string", "x-jsonschema2go": {"noOmitEmpty": true}}
+					},
+					"x-jsonschema2go": {"fieldAliases": {"arn": "ARN"}}
+				}`,
+			},
+		},
+		{
+			name: "unknown tag option",
+			src: `package foo
+
+type Bar struct {
+	Name string ` + "`" + `json:"name" jsonschema:"minSize=1"` + "`" + `
+}
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			f, err := parser.ParseFile(token.NewFileSet(), "values.go", tt.src, parser.ParseComments)
+			r.NoError(err)
+
+			docs, err := FromFiles([]*ast.File{f}, tt.conf)
+			if tt.wantErr {
+				r.Error(err)
+			} else {
+				r.NoError(err)
+			}
+
+			got := make(map[string]string)
+			for _, d := range docs {
+				r.Equal(d.ID, d.Schema["$id"])
+				b, err := json.Marshal(d.Schema)
+				r.NoError(err)
+				got[d.Name] = string(b)
+			}
+			r.Len(got, len(tt.want))
+			for name, want := range tt.want {
+				r.JSONEq(want, got[name], name)
+			}
+		})
+	}
+}
+
+func TestFromDir(t *testing.T) {
+	tests := []struct {
+		planner string // the package of the golden test, composite if unset
+		dir     string
+		want    string
+		others  []string // the names of the other documents derived
+	}{
+		{
+			dir: "example",
+			want: `{
+				"$id": "https://example.com/testdata/generate/example/foo/bar.json",
+				"description": "Bar contains some info",
+				"type": "object",
+				"properties": {
+					"baz": {"type": "string", "pattern": "^[0-9a-fA-F]{10}$"},
+					"count": {"type": "integer", "minimum": 3}
+				},
+				"required": ["baz"]
+			}`,
+		},
+		{
+			dir: "pointers",
+			want: `{
+				"$id": "https://example.com/testdata/generate/pointers/foo/bar.json",
+				"description": "Bar's required fields are values",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "minLength": 1, "x-jsonschema2go": {"pointers": "optional"}},
+					"count": {"type": "integer", "minimum": 1},
+					"enabled": {"type": "boolean"},
+					"baz": {
+						"type": "object",
+						"properties": {
+							"id": {"type": "string", "x-jsonschema2go": {"pointers": "optional"}},
+							"label": {"type": "string", "maxLength": 10, "x-jsonschema2go": {"pointers": "generic"}},
+							"ratio": {"type": "number", "maximum": 1, "x-jsonschema2go": {"pointers": "optional"}}
+						},
+						"required": ["id", "ratio"]
+					}
+				},
+				"required": ["baz", "enabled", "name"]
+			}`,
+		},
+		{
+			dir: "nullable",
+			want: `{
+				"$id": "https://example.com/testdata/generate/nullable/foo/bar.json",
+				"description": "Bar distinguishes null from unset",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "x-jsonschema2go": {"pointers": "nullable"}},
+					"nickname": {"type": ["string", "null"], "x-jsonschema2go": {"pointers": "nullable"}},
+					"settings": {
+						"type": "object",
+						"properties": {"verbose": {"type": "boolean"}},
+						"x-jsonschema2go": {"pointers": "nullable"}
+					}
+				},
+				"required": ["nickname"]
+			}`,
+		},
+		{
+			dir: "content_encoding",
+			want: `{
+				"$id": "https://example.com/testdata/generate/content_encoding/foo/bar.json",
+				"type": "object",
+				"properties": {
+					"checksum": {"type": "string", "contentEncoding": "base64", "maxLength": 44},
+					"config": {"type": "string", "contentMediaType": "application/json"},
+					"data": {"type": "string", "contentEncoding": "base64", "x-jsonschema2go": {"noOmitEmpty": true}},
+					"document": {"type": "string", "contentEncoding": "base64", "contentMediaType": "application/json"},
+					"report": {"type": "string", "contentMediaType": "application/vnd.example.100%+json"}
+				},
+				"required": ["data"]
+			}`,
+		},
+		{
+			dir: "oneof_diff_types",
+			want: `{
+				"$id": "https://example.com/testdata/generate/oneof_diff_types/foo/bar.json",
+				"description": "Bar gives you some dumb info",
+				"oneOf": [
+					{"type": "object", "properties": {"baz": {"type": "string"}}},
+					{"type": "array", "items": {"type": "string"}},
+					{"type": "string", "pattern": "^[0-9]{22}$"},
+					{"type": "number", "minimum": 3.7},
+					{"type": "boolean"},
+					{"type": "null"}
+				]
+			}`,
+		},
+		{
+			dir: "oneof_objects",
+			want: `{
+				"$id": "https://example.com/testdata/generate/oneof_objects/foo/bar.json",
+				"description": "Bar is either a person or a group",
+				"type": "object",
+				"properties": {
+					"owner": {
+						"oneOf": [
+							{"$ref": "https://example.com/testdata/generate/oneof_objects/foo/person.json"},
+							{"$ref": "https://example.com/testdata/generate/oneof_objects/foo/group.json"}
+						]
+					}
+				},
+				"required": ["owner"]
+			}`,
+			others: []string{"Group", "Person"},
+		},
+		{
+			// under the default pointer policy a nullable primitive is a pointer, as is an optional one, so null is
+			// only recovered from jsoptional.Nullable
+			dir: "type_array_nullable",
+			want: `{
+				"$id": "https://example.com/testdata/generate/type_array_nullable/foo/bar.json",
+				"description": "Bar has fields of several types, some of which may be null",
+				"type": "object",
+				"properties": {
+					"count": {"type": "integer", "x-jsonschema2go": {"pointers": "nullable"}},
+					"id": {
+						"description": "either a number or a name",
+						"type": ["integer", "string", "null"],
+						"x-jsonschema2go": {"pointers": "nullable"}
+					},
+					"nickname": {"type": ["string", "null"], "maxLength": 20, "x-jsonschema2go": {"pointers": "nullable"}},
+					"tags": {
+						"type": ["object", "array", "null"],
+						"items": {"type": "string"},
+						"additionalProperties": {"type": "string"},
+						"x-jsonschema2go": {"pointers": "nullable"}
+					}
+				}
+			}`,
+		},
+		{
+			planner: "tuple",
+			dir:     "tuple_oneof",
+			want: `{
+				"$id": "https://example.com/testdata/generate/tuple_oneof/foo/bar.json",
+				"description": "Bar gives you some dumb info",
+				"type": "array",
+				"items": [
+					{"type": "string", "pattern": "^abcdef$"},
+					{"type": "number", "minimum": 42.3},
+					{"oneOf": [{"type": "integer"}, {"type": "boolean"}]}
+				]
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			r := require.New(t)
+
+			planner := tt.planner
+			if planner == "" {
+				planner = "composite"
+			}
+			docs, err := FromDir("../../internal/"+planner+"/testdata/generate/"+tt.dir+"/foo", Config{})
+			r.NoError(err)
+
+			var names []string
+			for _, d := range docs {
+				names = append(names, d.Name)
+			}
+			r.Equal(append([]string{"Bar"}, tt.others...), names)
+
+			b, err := json.Marshal(docs[0].Schema)
+			r.NoError(err)
+			r.JSONEq(tt.want, string(b))
+		})
+	}
+}
+
+// TestFromDir_goldens derives schemas from the code of every golden test of generation, so that changing how checks
+// are generated fails here rather than losing them
+func TestFromDir_goldens(t *testing.T) {
+	// the goldens which can't be derived, by the reason they can't
+	unsupported := map[string]string{
+		"composite/testdata/generate/exclude/foo":      `field Inner: unknown type "Excluded"`,
+		"composite/testdata/generate/oneof_object/foo": "Bar implements its own JSON decoding",
+	}
+
+	var dirs []string
+	r := require.New(t)
+	r.NoError(filepath.Walk("../../internal", func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() || !strings.Contains(p, "/testdata/generate/") {
+			return err
+		}
+		if files, err := filepath.Glob(filepath.Join(p, "*.gen.go")); err != nil || len(files) > 0 {
+			dirs = append(dirs, p)
+			return err
+		}
+		return nil
+	}))
+	r.NotEmpty(dirs)
+
+	for _, dir := range dirs {
+		name := strings.TrimPrefix(dir, "../../internal/")
+		t.Run(name, func(t *testing.T) {
+			_, err := FromDir(dir, Config{})
+			if reason, ok := unsupported[name]; ok {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), reason)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
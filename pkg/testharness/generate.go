@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"sort"
@@ -72,13 +73,22 @@ func RunGenerateTests(t *testing.T, testDataDir, root, goPath string) {
 			r.NoError(err)
 
 			golden := false
+			lang := ""
 			paths := make([]string, 0, len(args))
+			options := []jsonschema2go.Option{
+				jsonschema2go.TypeFromID("https://example.com/testdata", goPath),
+				jsonschema2go.Debug(true),
+			}
 			for _, a := range args {
-				if a == "GOLDEN" {
+				switch {
+				case a == "GOLDEN":
 					golden = true
-					continue
+				case strings.HasPrefix(a, "go1."): // the Go version targeted by the generated code
+					options = append(options, jsonschema2go.GoVersion(a))
+					lang = a
+				default:
+					paths = append(paths, "file:"+path.Join(testDir, a))
 				}
-				paths = append(paths, "file:"+path.Join(testDir, a))
 			}
 
 			dirName, err := os.MkdirTemp("", e.Name())
@@ -88,9 +98,7 @@ func RunGenerateTests(t *testing.T, testDataDir, root, goPath string) {
 			r.NoError(jsonschema2go.Generate(
 				context.Background(),
 				paths,
-				jsonschema2go.PrefixMap(goPath, dirName),
-				jsonschema2go.TypeFromID("https://example.com/testdata", goPath),
-				jsonschema2go.Debug(true),
+				append(options, jsonschema2go.PrefixMap(goPath, dirName))...,
 			))
 			results, err := listAllFiles(dirName, ".gen.go")
 			r.NoError(err)
@@ -131,6 +139,20 @@ func RunGenerateTests(t *testing.T, testDataDir, root, goPath string) {
 
 				r.Equal(wanted, result, k)
 			}
+
+			if lang != "" {
+				// the golden code must compile with only the targeted version's language features
+				dirs := make(map[string]bool)
+				for _, p := range wanted {
+					dirs[filepath.Dir(p)] = true
+				}
+				cmdArgs := []string{"build", "-gcflags=-lang=" + lang}
+				for d := range dirs {
+					cmdArgs = append(cmdArgs, d)
+				}
+				out, err := exec.Command("go", cmdArgs...).CombinedOutput()
+				r.NoError(err, string(out))
+			}
 		})
 	}
 }
@@ -55,7 +55,7 @@ func RunValidationTest(t *testing.T, root string) {
 					if sc.Skip != "" {
 						t.Skip(sc.Skip)
 					}
-					v := compileValidator(ctx, require.New(t), sc.Schema)
+					v := compileValidator(ctx, require.New(t), sc.Schema, sc.GoVersion)
 					defer v.Close()
 
 					for _, tc := range sc.Tests {
@@ -69,8 +69,16 @@ func RunValidationTest(t *testing.T, root string) {
 
 							f, _ := ioutil.ReadFile(v.valuesPath)
 
+							// a valid value is followed by its encoding once its defaults are applied
+							res, defaulted := splitResult(res)
 							if tc.Valid {
 								r.Equal("valid", res, "got this: "+errS+string(f))
+								if tc.DefaultsErr {
+									r.Equal("err_defaults", defaulted, string(f))
+								}
+								if tc.Defaults != nil {
+									r.JSONEq(string(tc.Defaults), defaulted, string(f))
+								}
 								return
 							}
 
@@ -83,6 +91,14 @@ func RunValidationTest(t *testing.T, root string) {
 	}
 }
 
+func splitResult(res string) (string, string) {
+	parts := strings.SplitN(res, "\n", 2)
+	if len(parts) < 2 {
+		return res, ""
+	}
+	return parts[0], parts[1]
+}
+
 type validator struct {
 	workDir, harnessPath, valuesPath string
 }
@@ -101,7 +117,7 @@ func (v *validator) Close() error {
 	return os.RemoveAll(v.workDir)
 }
 
-func compileValidator(ctx context.Context, r *require.Assertions, schema json.RawMessage) *validator {
+func compileValidator(ctx context.Context, r *require.Assertions, schema json.RawMessage, goVersion string) *validator {
 	dirName, err := ioutil.TempDir("", "")
 	r.NoError(err)
 
@@ -129,12 +145,13 @@ func compileValidator(ctx context.Context, r *require.Assertions, schema json.Ra
 			defer mux.Unlock()
 
 			if _, ok := names[schema]; !ok {
-				names[schema] = string('a' + len(names))
+				names[schema] = string(rune('a' + len(names)))
 			}
 
 			return gen.TypeInfo{GoPath: "main", Name: names[schema]}
 		}),
 		jsonschema2go.PrefixMap("main", dirName),
+		jsonschema2go.GoVersion(goVersion),
 	))
 
 	_, err = os.Stat(path.Join(dirName, "values.gen.go"))
@@ -165,8 +182,25 @@ func main() {
 		return
 	}
 	fmt.Fprint(os.Stdout, "valid")
+	if v, ok := interface{}(&val).(interface{ ApplyDefaults() error }); ok {
+		if err := v.ApplyDefaults(); err != nil {
+			fmt.Fprint(os.Stdout, "\nerr_defaults")
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+	}
+	b, err := json.Marshal(val) // by value, as when held in a map, so that methods with pointer receivers are skipped
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Fprintf(os.Stdout, "\n%s", b)
 }
 `)
+	lang := "go1.13"
+	if goVersion != "" {
+		lang = goVersion
+	}
 	harnessPath := path.Join(dirName, "testharness")
 	mainPath := path.Join(dirName, "main.go")
 	valuesPath := path.Join(dirName, "values.gen.go")
@@ -174,6 +208,7 @@ func main() {
 		ctx,
 		"go",
 		"build",
+		"-gcflags=-lang="+lang, // the generated code must compile with the targeted version's language features
 		"-o",
 		harnessPath,
 		mainPath,
@@ -192,6 +227,7 @@ type schemaCase struct {
 	Schema      json.RawMessage `json:"schema"`
 	Tests       []testCase      `json:"tests"`
 	Skip        string          `json:"skip"`
+	GoVersion   string          `json:"goVersion"` // the Go version targeted by the generated code, e.g. "go1.18"
 }
 
 type testCase struct {
@@ -199,5 +235,7 @@ type testCase struct {
 	Data        json.RawMessage `json:"data"`
 	Output      json.RawMessage `json:"output"`
 	Valid       bool            `json:"valid"`
+	Defaults    json.RawMessage `json:"defaults"`    // the value once its defaults are applied, if checked
+	DefaultsErr bool            `json:"defaultsErr"` // whether applying its defaults fails
 	Skip        string          `json:"skip"`
 }